@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/aminshahid573/termplay/internal/config"
 	"github.com/aminshahid573/termplay/internal/db"
+	_ "github.com/aminshahid573/termplay/internal/games" // Registers every game
 	"github.com/aminshahid573/termplay/internal/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,27 +22,40 @@ import (
 	"github.com/charmbracelet/wish/activeterm"
 	bm "github.com/charmbracelet/wish/bubbletea"
 	"github.com/charmbracelet/wish/logging"
+	"github.com/muesli/termenv"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 var cleanupWg sync.WaitGroup
 
 func main() {
 	// 1. Init DB
-	if err := db.Init(); err != nil {
-		log.Fatal("Failed to init Firebase", "err", err)
+	store, err := db.Open()
+	if err != nil {
+		log.Fatal("Failed to init storage", "backend", config.Storage, "err", err)
 	}
 
 	// Cleanup old rooms on startup
-	go db.CleanZombies()
+	go store.CleanZombies()
+
+	// One shared feed per room instead of one poller per session
+	hub := ui.NewHub(store)
 
 	// 2. Setup SSH
 	s, err := wish.NewServer(
 		wish.WithAddress(fmt.Sprintf("%s:%d", config.Host, config.Port)),
 		wish.WithHostKeyPath("ssh_host_key"),
+		// Any key is welcome: it is only asked for so a player's fingerprint
+		// can carry their high scores between logins. Clients without a key
+		// still get in through keyboard-interactive.
+		wish.WithPublicKeyAuth(func(ssh.Context, ssh.PublicKey) bool { return true }),
+		wish.WithKeyboardInteractiveAuth(func(ssh.Context, gossh.KeyboardInteractiveChallenge) bool { return true }),
 		wish.WithMiddleware(
-			bm.Middleware(teaHandler),
+			bm.MiddlewareWithProgramHandler(programHandler(store, hub), termenv.Ascii),
 			logging.Middleware(),
 			activeterm.Middleware(),
+			// Runs before activeterm so commands work without a pty
+			commandMiddleware(store),
 		),
 	)
 	if err != nil {
@@ -72,7 +87,45 @@ func main() {
 	log.Info("Shutdown complete")
 }
 
-func teaHandler(s ssh.Session) (tea.Model, []tea.ProgramOption) {
+// commandMiddleware answers non-interactive commands, e.g.
+// `ssh -p 2324 host pgn ABCD` for a chess game or `sgf ABCD` for Go, and
+// hands plain logins on to the TUI.
+func commandMiddleware(store db.Store) wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			args := s.Command()
+			if len(args) == 0 {
+				next(s)
+				return
+			}
+
+			switch args[0] {
+			case "pgn", "sgf":
+				// Each game has one record format, so both names fetch it
+				if len(args) != 2 {
+					wish.Fatalln(s, "usage: "+args[0]+" <ROOM CODE>")
+					return
+				}
+				record, err := store.GetRecord(strings.ToUpper(args[1]))
+				if err != nil {
+					wish.Fatalln(s, err)
+					return
+				}
+				wish.Print(s, record)
+			default:
+				wish.Fatalln(s, fmt.Sprintf("unknown command %q (try: pgn or sgf <ROOM CODE>)", args[0]))
+			}
+		}
+	}
+}
+
+func programHandler(store db.Store, hub *ui.Hub) bm.ProgramHandler {
+	return func(s ssh.Session) *tea.Program {
+		return newSession(s, store, hub)
+	}
+}
+
+func newSession(s ssh.Session, store db.Store, hub *ui.Hub) *tea.Program {
 	cleanup := &ui.CleanupState{}
 
 	cleanupWg.Add(1)
@@ -85,12 +138,21 @@ func teaHandler(s ssh.Session) (tea.Model, []tea.ProgramOption) {
 		defer cleanup.Mu.Unlock()
 
 		if cleanup.RoomCode != "" {
+			hub.Unsubscribe(cleanup.RoomCode, cleanup.Program)
+			hub.LeaveLive(cleanup.RoomCode, cleanup.SessionID, cleanup.Program)
 			log.Info("Cleaning up room", "code", cleanup.RoomCode, "id", cleanup.SessionID)
-			if err := db.LeaveRoom(cleanup.RoomCode, cleanup.SessionID, cleanup.IsHost); err != nil {
+			if err := store.LeaveRoom(cleanup.RoomCode, cleanup.SessionID, cleanup.IsHost); err != nil {
 				log.Error("Cleanup Error", "err", err)
 			}
 		}
 	}()
 
-	return ui.InitialModel(s, cleanup), []tea.ProgramOption{tea.WithAltScreen()}
+	m := ui.InitialModel(s, cleanup, store, hub)
+	opts := append(bm.MakeOptions(s), tea.WithAltScreen())
+	p := tea.NewProgram(m, opts...)
+
+	cleanup.Mu.Lock()
+	cleanup.Program = p
+	cleanup.Mu.Unlock()
+	return p
 }
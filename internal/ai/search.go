@@ -0,0 +1,194 @@
+// Package ai holds a game-agnostic alpha-beta search. Each game package
+// adapts its own rules to the Game interface below.
+package ai
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Win and Loss bound every score. Finished games score exactly Win, Loss
+// or 0; the search shifts them by depth so faster wins rank higher.
+const (
+	Win  = 1_000_000
+	Loss = -Win
+)
+
+// Game is everything the search needs to know about a two-player,
+// zero-sum, perfect-information game with states S and moves M.
+type Game[S, M any] interface {
+	// Moves lists the legal moves in s. Listing likely good moves first
+	// makes the search prune much more.
+	Moves(s S) []M
+	// Play returns the state after m, leaving s untouched
+	Play(s S, m M) S
+	// Score rates s for the side to move. over reports a finished game,
+	// whose score must be Win, Loss or 0.
+	Score(s S) (score int, over bool)
+}
+
+// Tactical is implemented by games whose Score can't be trusted in the
+// middle of an exchange. Past its depth the search keeps playing
+// NoisyMoves, such as captures, until the position settles.
+type Tactical[S, M any] interface {
+	NoisyMoves(s S) []M
+}
+
+// maxQuiescence caps how many noisy plies are played past the depth
+const maxQuiescence = 6
+
+// Level is an engine strength
+type Level struct {
+	Name string
+	// Depth is the deepest search, in plies. 0 plays a random legal move.
+	Depth int
+	// Budget caps thinking time; the deepest finished search is used
+	Budget time.Duration
+	// Noise is the largest random bonus given to each root move, so weak
+	// levels pick decent rather than best moves. 0 plays the best move.
+	Noise int
+}
+
+// Search picks a move for the side to move in s. It deepens one ply at a
+// time until lvl.Depth, lvl.Budget or ctx runs out, and returns the choice
+// of the deepest search that finished. ok is false when s has no moves.
+func Search[S, M any](ctx context.Context, g Game[S, M], s S, lvl Level) (best M, ok bool) {
+	moves := g.Moves(s)
+	if len(moves) == 0 {
+		return best, false
+	}
+	best, ok = moves[0], true
+	if len(moves) == 1 {
+		return best, true
+	}
+	if lvl.Depth == 0 {
+		return moves[rand.Intn(len(moves))], true
+	}
+
+	if lvl.Budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, lvl.Budget)
+		defer cancel()
+	}
+
+	// Noise is rolled once so every depth agrees on it
+	noise := make([]int, len(moves))
+	if lvl.Noise > 0 {
+		for i := range noise {
+			noise[i] = rand.Intn(lvl.Noise + 1)
+		}
+	}
+
+	sr := &searcher[S, M]{g: g, ctx: ctx}
+	for depth := 1; depth <= lvl.Depth; depth++ {
+		bestIdx, bestScore := -1, Loss-1
+		for i, m := range moves {
+			// Noisy roots need real scores for every move, so only a
+			// noiseless search can narrow the window
+			alpha := Loss - 1
+			if lvl.Noise == 0 {
+				alpha = bestScore
+			}
+			score := -sr.negamax(g.Play(s, m), depth-1, 1, -(Win + 1), -alpha)
+			if sr.stopped {
+				return best, true
+			}
+			if score > Loss/2 && score < Win/2 {
+				score += noise[i]
+			}
+			if score > bestScore {
+				bestIdx, bestScore = i, score
+			}
+		}
+		best = moves[bestIdx]
+
+		// Try last depth's best first next time round
+		moves[0], moves[bestIdx] = moves[bestIdx], moves[0]
+		noise[0], noise[bestIdx] = noise[bestIdx], noise[0]
+
+		if bestScore >= Win/2 {
+			break // Forced win found, no need to look further
+		}
+	}
+	return best, true
+}
+
+type searcher[S, M any] struct {
+	g       Game[S, M]
+	ctx     context.Context
+	nodes   int
+	stopped bool
+}
+
+// negamax scores s for the side to move, searching depth more plies
+func (sr *searcher[S, M]) negamax(s S, depth, ply, alpha, beta int) int {
+	// Checking the context is not free, so only do it now and then
+	sr.nodes++
+	if sr.nodes&255 == 0 && sr.ctx.Err() != nil {
+		sr.stopped = true
+	}
+	if sr.stopped {
+		return 0
+	}
+
+	score, over := sr.g.Score(s)
+	if over {
+		switch {
+		case score >= Win:
+			return Win - ply
+		case score <= Loss:
+			return Loss + ply
+		}
+		return 0
+	}
+	if depth == 0 {
+		return sr.quiesce(s, score, ply, maxQuiescence, alpha, beta)
+	}
+
+	moves := sr.g.Moves(s)
+	if len(moves) == 0 {
+		return score
+	}
+	for _, m := range moves {
+		v := -sr.negamax(sr.g.Play(s, m), depth-1, ply+1, -beta, -alpha)
+		if v > alpha {
+			alpha = v
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return alpha
+}
+
+// quiesce extends a leaf through noisy moves only. The side to move may
+// also stand pat on score, since it isn't forced to capture.
+func (sr *searcher[S, M]) quiesce(s S, score, ply, left, alpha, beta int) int {
+	t, ok := sr.g.(Tactical[S, M])
+	if !ok || left == 0 || score >= beta {
+		return score
+	}
+	if score > alpha {
+		alpha = score
+	}
+	for _, m := range t.NoisyMoves(s) {
+		next := sr.g.Play(s, m)
+		v, over := sr.g.Score(next)
+		if over {
+			v = -sr.negamax(next, 0, ply+1, -beta, -alpha)
+		} else {
+			v = -sr.quiesce(next, v, ply+1, left-1, -beta, -alpha)
+		}
+		if sr.stopped {
+			return 0
+		}
+		if v > alpha {
+			alpha = v
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return alpha
+}
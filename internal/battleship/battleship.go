@@ -0,0 +1,339 @@
+// Package battleship holds the rules for Battleship. Each player secretly
+// places a fleet on their own 10×10 grid, then they take turns firing at
+// each other's. The first to sink the whole enemy fleet wins.
+package battleship
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Size is the width and height of each grid
+const Size = 10
+
+// Phases of a game. Both players place their fleets at once; firing
+// starts when both are done.
+const (
+	PhasePlacing  = "placing"
+	PhaseFiring   = "firing"
+	PhaseFinished = "finished"
+)
+
+// ShipType is one kind of ship in the fleet
+type ShipType struct {
+	Name string
+	Size int
+}
+
+// Fleet is every player's fleet, in the order ships are placed
+var Fleet = []ShipType{
+	{"Carrier", 5},
+	{"Battleship", 4},
+	{"Cruiser", 3},
+	{"Submarine", 3},
+	{"Destroyer", 2},
+}
+
+// Pos is a cell on a grid, row 0 at the top
+type Pos struct {
+	Row, Col int
+}
+
+// Ship is a placed ship. Row, Col is its top or left end.
+type Ship struct {
+	Name     string `json:"name"`
+	Size     int    `json:"size"`
+	Row      int    `json:"row"`
+	Col      int    `json:"col"`
+	Vertical bool   `json:"vertical,omitempty"`
+}
+
+// Cells are the cells the ship covers
+func (sh Ship) Cells() []Pos {
+	cells := make([]Pos, sh.Size)
+	for i := range cells {
+		cells[i] = Pos{Row: sh.Row, Col: sh.Col + i}
+		if sh.Vertical {
+			cells[i] = Pos{Row: sh.Row + i, Col: sh.Col}
+		}
+	}
+	return cells
+}
+
+// Covers reports whether the ship lies on p
+func (sh Ship) Covers(p Pos) bool {
+	if sh.Vertical {
+		return p.Col == sh.Col && p.Row >= sh.Row && p.Row < sh.Row+sh.Size
+	}
+	return p.Row == sh.Row && p.Col >= sh.Col && p.Col < sh.Col+sh.Size
+}
+
+// Shot is one shot fired. Sunk names the ship it sent down, if any.
+type Shot struct {
+	Row  int    `json:"row"`
+	Col  int    `json:"col"`
+	Hit  bool   `json:"hit,omitempty"`
+	Sunk string `json:"sunk,omitempty"`
+}
+
+// State is a whole game. ShipsX are the ships on X's grid and ShotsX the
+// shots X has fired at O's. What a session sees is redacted: the other
+// player's ships are left out until they are sunk.
+type State struct {
+	Phase  string   `json:"phase"`
+	Turn   string   `json:"turn"`             // Seat to fire next, or first once firing starts
+	Placed []string `json:"placed,omitempty"` // Seats whose fleets are in place
+	ShipsX []Ship   `json:"shipsX,omitempty"`
+	ShipsO []Ship   `json:"shipsO,omitempty"`
+	ShotsX []Shot   `json:"shotsX,omitempty"`
+	ShotsO []Shot   `json:"shotsO,omitempty"`
+	Winner string   `json:"winner,omitempty"`
+}
+
+// New returns a game waiting for both fleets, with first to fire
+func New(first string) State {
+	return State{Phase: PhasePlacing, Turn: first}
+}
+
+// Ships are the ships on seat's grid, as far as this state knows them
+func (s State) Ships(seat string) []Ship {
+	if seat == game.X {
+		return s.ShipsX
+	}
+	return s.ShipsO
+}
+
+// Shots are the shots seat has fired
+func (s State) Shots(seat string) []Shot {
+	if seat == game.X {
+		return s.ShotsX
+	}
+	return s.ShotsO
+}
+
+// HasPlaced reports whether seat's fleet is in place
+func (s State) HasPlaced(seat string) bool {
+	for _, p := range s.Placed {
+		if p == seat {
+			return true
+		}
+	}
+	return false
+}
+
+// ShotAt returns the shot seat fired at p, if any
+func (s State) ShotAt(seat string, p Pos) (Shot, bool) {
+	for _, sh := range s.Shots(seat) {
+		if sh.Row == p.Row && sh.Col == p.Col {
+			return sh, true
+		}
+	}
+	return Shot{}, false
+}
+
+// PosName writes p as a row letter and column number, e.g. "B7"
+func PosName(p Pos) string {
+	return string(rune('A'+p.Row)) + strconv.Itoa(p.Col+1)
+}
+
+// ParsePos reads a cell written by PosName
+func ParsePos(name string) (Pos, error) {
+	name = strings.ToUpper(name)
+	if len(name) < 2 {
+		return Pos{}, fmt.Errorf("cell %q is not like B7", name)
+	}
+	col, err := strconv.Atoi(name[1:])
+	p := Pos{Row: int(name[0] - 'A'), Col: col - 1}
+	if err != nil || !onGrid(p) {
+		return Pos{}, fmt.Errorf("cell %q is off the grid", name)
+	}
+	return p, nil
+}
+
+func onGrid(p Pos) bool {
+	return p.Row >= 0 && p.Row < Size && p.Col >= 0 && p.Col < Size
+}
+
+// ShipName writes a placed ship as its end cell and H or V, e.g. "B7V"
+func ShipName(sh Ship) string {
+	dir := "H"
+	if sh.Vertical {
+		dir = "V"
+	}
+	return PosName(Pos{Row: sh.Row, Col: sh.Col}) + dir
+}
+
+// FleetMove is the move that places ships, which must follow Fleet
+func FleetMove(ships []Ship) string {
+	names := make([]string, len(ships))
+	for i, sh := range ships {
+		names[i] = ShipName(sh)
+	}
+	return "fleet " + strings.Join(names, " ")
+}
+
+// ParseFleet reads the ships of a fleet move and checks they fit the grid
+// without overlapping
+func ParseFleet(arg string) ([]Ship, error) {
+	fields := strings.Fields(arg)
+	if len(fields) != len(Fleet) {
+		return nil, fmt.Errorf("a fleet is %d ships", len(Fleet))
+	}
+	var ships []Ship
+	for i, f := range fields {
+		dir := strings.ToUpper(f[len(f)-1:])
+		if dir != "H" && dir != "V" {
+			return nil, fmt.Errorf("ship %q must end in H or V", f)
+		}
+		p, err := ParsePos(f[:len(f)-1])
+		if err != nil {
+			return nil, err
+		}
+		sh := Ship{Name: Fleet[i].Name, Size: Fleet[i].Size, Row: p.Row, Col: p.Col, Vertical: dir == "V"}
+		if err := Fits(ships, sh); err != nil {
+			return nil, err
+		}
+		ships = append(ships, sh)
+	}
+	return ships, nil
+}
+
+// Fits says why sh can't join ships, or nil if it can
+func Fits(ships []Ship, sh Ship) error {
+	for _, c := range sh.Cells() {
+		if !onGrid(c) {
+			return fmt.Errorf("the %s runs off the grid", sh.Name)
+		}
+		for _, other := range ships {
+			if other.Covers(c) {
+				return fmt.Errorf("the %s overlaps the %s", sh.Name, other.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// RandomFleet places the rest of a fleet anywhere it fits, after the ships
+// already placed
+func RandomFleet(placed []Ship) []Ship {
+	ships := append([]Ship(nil), placed...)
+	for _, t := range Fleet[len(ships):] {
+		for {
+			sh := Ship{Name: t.Name, Size: t.Size, Row: rand.Intn(Size), Col: rand.Intn(Size), Vertical: rand.Intn(2) == 0}
+			if Fits(ships, sh) == nil {
+				ships = append(ships, sh)
+				break
+			}
+		}
+	}
+	return ships
+}
+
+// Place puts seat's fleet in the state. Firing starts once both have.
+func Place(s State, seat string, ships []Ship) State {
+	if seat == game.X {
+		s.ShipsX = ships
+	} else {
+		s.ShipsO = ships
+	}
+	s.Placed = append(append([]string(nil), s.Placed...), seat)
+	if len(s.Placed) == 2 {
+		s.Phase = PhaseFiring
+	}
+	return s
+}
+
+// Fire has the side to move shoot at p on the other grid, which must not
+// have been shot at yet. The turn passes either way; sinking the last ship
+// wins.
+func Fire(s State, p Pos) State {
+	shooter, target := s.Turn, game.Other(s.Turn)
+	shot := Shot{Row: p.Row, Col: p.Col}
+	shots := append(append([]Shot(nil), s.Shots(shooter)...), shot)
+
+	sunk := 0
+	for _, sh := range s.Ships(target) {
+		if sh.Covers(p) {
+			shot.Hit = true
+		}
+		if allHit(sh, shots) {
+			if sh.Covers(p) {
+				shot.Sunk = sh.Name
+			}
+			sunk++
+		}
+	}
+	shots[len(shots)-1] = shot
+
+	if shooter == game.X {
+		s.ShotsX = shots
+	} else {
+		s.ShotsO = shots
+	}
+	s.Turn = target
+	if sunk == len(Fleet) {
+		s.Phase = PhaseFinished
+		s.Winner = shooter
+	}
+	return s
+}
+
+// allHit reports whether every cell of sh has been shot
+func allHit(sh Ship, shots []Shot) bool {
+	for _, c := range sh.Cells() {
+		hit := false
+		for _, shot := range shots {
+			if shot.Row == c.Row && shot.Col == c.Col {
+				hit = true
+				break
+			}
+		}
+		if !hit {
+			return false
+		}
+	}
+	return true
+}
+
+// Sunk reports whether the ship named name on seat's grid has gone down
+func (s State) Sunk(seat, name string) bool {
+	for _, shot := range s.Shots(game.Other(seat)) {
+		if shot.Sunk == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact hides what seat may not see: the other fleet's ships that are
+// still afloat. Spectators ("") see only the shots; a sunk ship shows
+// there by name, but not where it lay.
+func (s State) Redact(seat string) game.State {
+	if seat == "" {
+		s.ShipsX, s.ShipsO = nil, nil
+		return s
+	}
+	if seat != game.X {
+		s.ShipsX = s.sunkShips(game.X)
+	}
+	if seat != game.O {
+		s.ShipsO = s.sunkShips(game.O)
+	}
+	return s
+}
+
+// sunkShips are seat's ships that have been sunk, whose places are no
+// secret any more
+func (s State) sunkShips(seat string) []Ship {
+	var ships []Ship
+	for _, sh := range s.Ships(seat) {
+		if s.Sunk(seat, sh.Name) {
+			ships = append(ships, sh)
+		}
+	}
+	return ships
+}
@@ -0,0 +1,93 @@
+package battleship
+
+import (
+	"testing"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// fleet is the same fleet for either side: a ship per row from A down,
+// each starting in column 1, so the Destroyer lies on E1 and E2
+const fleet = "fleet A1H B1H C1H D1H E1H"
+
+// play checks and applies moves in turn, from a new game in which X fires
+// first
+func play(t *testing.T, moves ...string) State {
+	t.Helper()
+	var s game.State = New(game.X)
+	for i, move := range moves {
+		seat := s.ToMove()
+		if i < 2 {
+			// Both fleets go in before the firing starts
+			seat = []string{game.X, game.O}[i]
+		}
+		if err := s.Check(seat, move); err != nil {
+			t.Fatalf("%s %q: %v", seat, move, err)
+		}
+		s = s.Apply(seat, move)
+	}
+	return s.(State)
+}
+
+func TestRedact(t *testing.T) {
+	// X hits O's Carrier and sinks its Destroyer; O misses twice
+	s := play(t, fleet, fleet, "fire A1", "fire J10", "fire E1", "fire J9", "fire E2")
+	if !s.Sunk(game.O, "Destroyer") || s.Sunk(game.X, "Destroyer") {
+		t.Fatal("only O's Destroyer should be down")
+	}
+	all := []string{"Carrier", "Battleship", "Cruiser", "Submarine", "Destroyer"}
+	tests := []struct {
+		name           string
+		seat           string
+		shipsX, shipsO []string
+	}{
+		{"X", game.X, all, []string{"Destroyer"}},
+		{"O", game.O, nil, all},
+		{"spectator", "", nil, nil},
+	}
+	names := func(ships []Ship) []string {
+		var ns []string
+		for _, sh := range ships {
+			ns = append(ns, sh.Name)
+		}
+		return ns
+	}
+	same := func(a, b []string) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := s.Redact(tt.seat).(State)
+			if got := names(r.ShipsX); !same(got, tt.shipsX) {
+				t.Errorf("%s sees X's ships %v, want %v", tt.name, got, tt.shipsX)
+			}
+			if got := names(r.ShipsO); !same(got, tt.shipsO) {
+				t.Errorf("%s sees O's ships %v, want %v", tt.name, got, tt.shipsO)
+			}
+			if len(r.ShotsX) != 3 || len(r.ShotsO) != 2 {
+				t.Errorf("%s sees %d and %d shots, want 3 and 2", tt.name, len(r.ShotsX), len(r.ShotsO))
+			}
+			if shot, ok := r.ShotAt(game.X, Pos{Row: 4, Col: 1}); !ok || !shot.Hit || shot.Sunk != "Destroyer" {
+				t.Errorf("%s sees the sinking shot as %+v", tt.name, shot)
+			}
+		})
+	}
+}
+
+func TestRedactLeavesStateAlone(t *testing.T) {
+	s := play(t, fleet, fleet, "fire E1", "fire J10", "fire E2")
+	for _, seat := range []string{game.X, game.O, ""} {
+		s.Redact(seat)
+	}
+	if len(s.ShipsX) != len(Fleet) || len(s.ShipsO) != len(Fleet) {
+		t.Errorf("redacting changed the full state: %d and %d ships", len(s.ShipsX), len(s.ShipsO))
+	}
+}
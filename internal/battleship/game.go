@@ -0,0 +1,87 @@
+package battleship
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Game is Battleship. Moves are "fleet A1H C3V ..." to place a whole fleet
+// at once, in Fleet order, and "fire B7" to shoot. The state is a
+// game.Secret, so neither player is ever sent the other's fleet.
+var Game = &game.Game{
+	ID:        "battleship",
+	Name:      "Battleship",
+	Seats:     [2]string{"Blue", "Red"},
+	Rules:     rules{},
+	NewScreen: newScreen,
+}
+
+type rules struct{}
+
+func (rules) New(variant any) (game.State, error) {
+	return New(game.X), nil
+}
+
+func (rules) Decode(data []byte) (game.State, error) {
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if s.Turn != game.X && s.Turn != game.O {
+		return nil, fmt.Errorf("stored game has no side to move")
+	}
+	return s, nil
+}
+
+func (s State) ToMove() string {
+	return s.Turn
+}
+
+func (s State) Check(seat, move string) error {
+	verb, arg, _ := strings.Cut(move, " ")
+	switch {
+	case s.Phase == PhaseFinished:
+		return fmt.Errorf("game is over")
+	case verb == "fleet":
+		if s.Phase != PhasePlacing || s.HasPlaced(seat) {
+			return fmt.Errorf("your fleet is already in place")
+		}
+		_, err := ParseFleet(arg)
+		return err
+	case verb != "fire":
+		return game.ErrIllegalMove
+	case s.Phase == PhasePlacing:
+		return fmt.Errorf("wait until both fleets are in place")
+	case seat != s.Turn:
+		return game.ErrNotYourTurn
+	}
+	p, err := ParsePos(arg)
+	if err != nil {
+		return err
+	}
+	if _, ok := s.ShotAt(seat, p); ok {
+		return fmt.Errorf("you already fired at %s", PosName(p))
+	}
+	return nil
+}
+
+func (s State) Apply(seat, move string) game.State {
+	verb, arg, _ := strings.Cut(move, " ")
+	if verb == "fleet" {
+		ships, _ := ParseFleet(arg)
+		return Place(s, seat, ships)
+	}
+	p, _ := ParsePos(arg)
+	return Fire(s, p)
+}
+
+func (s State) Outcome() game.Outcome {
+	return game.Outcome{Over: s.Phase == PhaseFinished, Winner: s.Winner}
+}
+
+func (s State) Rematch(first string) game.State {
+	return New(first)
+}
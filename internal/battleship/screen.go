@@ -0,0 +1,238 @@
+package battleship
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// screen is the cursor, on our own grid while placing and on the enemy's
+// while firing, and the ships placed so far. They stay on the client until
+// the whole fleet is sent at once.
+type screen struct {
+	r, c     int
+	vertical bool
+	ships    []Ship
+	sent     bool
+}
+
+func newScreen() game.Screen {
+	return screen{r: Size / 2, c: Size / 2}
+}
+
+// Sync starts placing afresh when a rematch begins
+func (sc screen) Sync(v game.View) game.Screen {
+	s := v.State.(State)
+	if sc.sent && s.Phase == PhasePlacing && !s.HasPlaced(v.Seat) {
+		sc.ships, sc.sent = nil, false
+	}
+	return sc
+}
+
+// placing reports whether the viewer still has a fleet to place
+func placing(v game.View, s State) bool {
+	return v.Seat != "" && v.Info.Status == "playing" && s.Phase == PhasePlacing && !s.HasPlaced(v.Seat)
+}
+
+// next is the ship the cursor is placing, at the cursor
+func (sc screen) next() Ship {
+	t := Fleet[len(sc.ships)]
+	return Ship{Name: t.Name, Size: t.Size, Row: sc.r, Col: sc.c, Vertical: sc.vertical}
+}
+
+func (sc screen) Update(v game.View, msg tea.KeyMsg) (game.Screen, game.Action) {
+	s := v.State.(State)
+	switch msg.String() {
+	case "up", "k":
+		sc.r = max(sc.r-1, 0)
+	case "down", "j":
+		sc.r = min(sc.r+1, Size-1)
+	case "left", "h":
+		sc.c = max(sc.c-1, 0)
+	case "right", "l":
+		sc.c = min(sc.c+1, Size-1)
+	case "o":
+		sc.vertical = !sc.vertical
+	case "a":
+		if placing(v, s) {
+			sc.ships = RandomFleet(sc.ships)
+		}
+	case "u", "backspace":
+		if placing(v, s) && len(sc.ships) > 0 {
+			sc.ships = sc.ships[:len(sc.ships)-1]
+		}
+	case "enter", " ":
+		switch {
+		case placing(v, s) && len(sc.ships) == len(Fleet):
+			sc.sent = true
+			return sc, game.Action{Move: FleetMove(sc.ships)}
+		case placing(v, s):
+			if sh := sc.next(); Fits(sc.ships, sh) == nil {
+				sc.ships = append(sc.ships, sh)
+			}
+		case s.Phase == PhaseFiring && v.MyTurn():
+			p := Pos{Row: sc.r, Col: sc.c}
+			if _, ok := s.ShotAt(v.Seat, p); !ok {
+				return sc, game.Action{Move: "fire " + PosName(p)}
+			}
+		}
+	default:
+		return sc, game.Action{Unhandled: true}
+	}
+	return sc, game.Action{}
+}
+
+func (sc screen) Help(v game.View) string {
+	s := v.State.(State)
+	if placing(v, s) {
+		return "arrows/hjkl move • enter/space place • o rotate • u undo • a auto-place • q quit"
+	}
+	return "arrows/hjkl move • enter/space fire • r restart • q quit"
+}
+
+func (sc screen) Render(v game.View) string {
+	s := v.State.(State)
+	sqW, sqH := game.SquareSize(Size, v.Width/2, v.Height)
+
+	// Players see their own fleet on the left and the enemy's waters on
+	// the right; spectators see both players' grids
+	left, right := game.X, game.O
+	leftTitle := v.Info.Name(game.X) + "'s fleet"
+	rightTitle := v.Info.Name(game.O) + "'s fleet"
+	if v.Seat != "" {
+		left, right = v.Seat, game.Other(v.Seat)
+		leftTitle, rightTitle = "YOUR FLEET", "ENEMY WATERS"
+	}
+
+	ships := s.Ships(left)
+	var preview *Ship
+	if placing(v, s) {
+		ships = sc.ships
+		if len(sc.ships) < len(Fleet) {
+			sh := sc.next()
+			preview = &sh
+		}
+	}
+	leftCursor, rightCursor := false, false
+	if v.Seat != "" {
+		leftCursor = placing(v, s)
+		rightCursor = !leftCursor && s.Phase != PhasePlacing
+	}
+
+	grids := lipgloss.JoinHorizontal(lipgloss.Top,
+		sc.renderSide(s, left, leftTitle, ships, preview, leftCursor, sqW, sqH),
+		"    ",
+		sc.renderSide(s, right, rightTitle, s.Ships(right), nil, rightCursor, sqW, sqH),
+	)
+
+	return game.BoardFrame(lipgloss.JoinVertical(lipgloss.Center,
+		styles.Title.Render("BATTLESHIP"),
+		game.Scoreboard(v),
+		"",
+		grids,
+		"",
+		sc.renderStatus(v, s),
+	))
+}
+
+// renderSide draws seat's grid under title with the ships we know of on
+// it, the other player's shots, and the state of the fleet below
+func (sc screen) renderSide(s State, seat, title string, ships []Ship, preview *Ship, cursor bool, sqW, sqH int) string {
+	files := make([]string, Size)
+	ranks := make([]string, Size)
+	for i := range Size {
+		files[i] = strconv.Itoa(i + 1)
+		ranks[i] = string(rune('A' + i))
+	}
+	previewOK := preview != nil && Fits(ships, *preview) == nil
+
+	grid := game.LabeledGrid(files, ranks, sqW, sqH, func(r, c int) string {
+		p := Pos{Row: r, Col: c}
+		bg, fg, text := styles.BattleshipWater, styles.BattleshipMiss, ""
+
+		var ship *Ship
+		for i := range ships {
+			if ships[i].Covers(p) {
+				ship = &ships[i]
+			}
+		}
+		if ship != nil {
+			bg = styles.BattleshipShip
+		}
+		if shot, ok := s.ShotAt(game.Other(seat), p); ok {
+			text = "•"
+			if shot.Hit {
+				text, fg = "✕", styles.CheckersWhitePiece
+				bg = styles.ChessCapture
+				if ship != nil && s.Sunk(seat, ship.Name) {
+					bg = styles.BattleshipSunk
+				}
+			}
+		}
+		if preview != nil && preview.Covers(p) {
+			bg = styles.ChessHighlight
+			if !previewOK {
+				bg = styles.ChessBlocked
+			}
+		}
+		if cursor && r == sc.r && c == sc.c {
+			bg = game.CursorSquare
+		}
+		return game.Square(text, fg, bg, sqW, sqH)
+	})
+
+	// Every ship by name, struck through once it is sunk
+	var fleet []string
+	for _, t := range Fleet {
+		style := styles.Subtle
+		if s.Sunk(seat, t.Name) {
+			style = styles.Err.Strikethrough(true)
+		}
+		fleet = append(fleet, style.Render(t.Name))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Center,
+		styles.Highlight.Render(title),
+		grid,
+		lipgloss.NewStyle().Width(lipgloss.Width(grid)).Align(lipgloss.Center).Render(strings.Join(fleet, "  ")),
+	)
+}
+
+// renderStatus says what everyone is waiting for, and what the last shot
+// did
+func (sc screen) renderStatus(v game.View, s State) string {
+	if s.Phase == PhasePlacing && v.Info.Status == "playing" {
+		switch {
+		case v.Seat == "":
+			return "Both admirals are placing their fleets..."
+		case placing(v, s) && len(sc.ships) == len(Fleet):
+			return styles.Special.Render("Fleet ready! Press enter to confirm, or u to undo")
+		case placing(v, s):
+			t := Fleet[len(sc.ships)]
+			return "Place your " + t.Name + " (" + strconv.Itoa(t.Size) + " cells)"
+		}
+		return "Waiting for " + v.Info.Name(game.Other(v.Seat)) + " to place their fleet..."
+	}
+
+	lines := []string{game.StatusLine(v, v.Game.SeatName(s.Turn))}
+	// The turn passes on every shot, so the last one came from the other side
+	shooter := game.Other(s.Turn)
+	if shots := s.Shots(shooter); len(shots) > 0 {
+		shot := shots[len(shots)-1]
+		result := "miss"
+		switch {
+		case shot.Sunk != "":
+			result = "sank the " + shot.Sunk + "!"
+		case shot.Hit:
+			result = "hit!"
+		}
+		name := PosName(Pos{Row: shot.Row, Col: shot.Col})
+		lines = append(lines, styles.Subtle.Render("Last shot: "+v.Game.SeatName(shooter)+" "+name+", "+result))
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, lines...)
+}
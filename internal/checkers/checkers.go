@@ -0,0 +1,346 @@
+// Package checkers holds the rules for English draughts: men move one
+// square diagonally forward, captures are compulsory and continue for as
+// long as the same piece can keep jumping, and a man reaching the far row
+// is crowned king and may then move backwards too.
+package checkers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Seats play the colours: X is Black and moves first from the top of the
+// board, O is White from the bottom
+const (
+	Black = "X"
+	White = "O"
+)
+
+const (
+	ReasonNoMoves    = "no moves left"
+	ReasonRepetition = "threefold repetition"
+	ReasonFortyMoves = "40-move rule"
+)
+
+// quietLimit is how many turns in a row without a capture or a man moving
+// make a draw: forty moves each
+const quietLimit = 80
+
+// Piece is one piece, or the zero Piece on an empty square
+type Piece struct {
+	Side string `json:"side,omitempty"` // Black or White
+	King bool   `json:"king,omitempty"`
+}
+
+// IsEmpty reports whether there is no piece here
+func (p Piece) IsEmpty() bool {
+	return p.Side == ""
+}
+
+// Pos is a square, row 0 at the top
+type Pos struct {
+	Row, Col int
+}
+
+func (p Pos) onBoard() bool {
+	return p.Row >= 0 && p.Row < 8 && p.Col >= 0 && p.Col < 8
+}
+
+// Dark reports whether p is one of the 32 squares that are played on
+func (p Pos) Dark() bool {
+	return (p.Row+p.Col)%2 == 1
+}
+
+// Number is p's square number in the usual 1 to 32 notation
+func (p Pos) Number() int {
+	return p.Row*4 + p.Col/2 + 1
+}
+
+// SquareAt is the dark square numbered n
+func SquareAt(n int) (Pos, error) {
+	if n < 1 || n > 32 {
+		return Pos{}, fmt.Errorf("square %d is off the board", n)
+	}
+	row := (n - 1) / 4
+	col := (n-1)%4*2 + 1 - row%2
+	return Pos{Row: row, Col: col}, nil
+}
+
+// State is a whole game. While a piece is partway through a multi-jump,
+// Chain is its square and the same side moves again. Rooms only store
+// whole turns, so Chain is only ever set between the hops of one.
+type State struct {
+	Board  [8][8]Piece `json:"board"`
+	Turn   string      `json:"turn"`
+	Chain  *Pos        `json:"chain,omitempty"`
+	Status string      `json:"status"` // "playing" or "finished"
+	Winner string      `json:"winner,omitempty"`
+	Reason string      `json:"reason,omitempty"`
+	Moves  []string    `json:"moves,omitempty"` // In square numbers, e.g. "11-15" or "15x24x31"
+
+	// Quiet counts turns since a capture or a man moved. Positions since
+	// then are kept in Seen to spot a third repetition.
+	Quiet int      `json:"quiet,omitempty"`
+	Seen  []string `json:"seen,omitempty"`
+}
+
+// New returns the starting position with first to move
+func New(first string) State {
+	s := State{Turn: first, Status: "playing"}
+	for r := 0; r < 8; r++ {
+		for c := 0; c < 8; c++ {
+			if !(Pos{Row: r, Col: c}).Dark() {
+				continue
+			}
+			switch {
+			case r < 3:
+				s.Board[r][c] = Piece{Side: Black}
+			case r > 4:
+				s.Board[r][c] = Piece{Side: White}
+			}
+		}
+	}
+	s.Seen = []string{s.key()}
+	return s
+}
+
+// Move is one step or one jump. A multi-jump is several, sent together as
+// one move like "15x24x31".
+type Move struct {
+	From, To Pos
+}
+
+// Jump reports whether m captures
+func (m Move) Jump() bool {
+	return m.To.Row-m.From.Row == 2 || m.From.Row-m.To.Row == 2
+}
+
+func (m Move) String() string {
+	sep := "-"
+	if m.Jump() {
+		sep = "x"
+	}
+	return strconv.Itoa(m.From.Number()) + sep + strconv.Itoa(m.To.Number())
+}
+
+// JoinMoves writes the hops of one turn as a single move, e.g. "15x24x31"
+func JoinMoves(hops []Move) string {
+	text := hops[0].String()
+	for _, m := range hops[1:] {
+		text += "x" + strconv.Itoa(m.To.Number())
+	}
+	return text
+}
+
+// ParseMoves reads a turn like "11-15", "15x24" or "15x24x31" into its hops
+func ParseMoves(s string) ([]Move, error) {
+	sep := "x"
+	if strings.Contains(s, "-") {
+		sep = "-"
+	}
+	parts := strings.Split(s, sep)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("move %q is not like 11-15 or 15x24x31", s)
+	}
+	squares := make([]Pos, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("move %q is not like 11-15 or 15x24x31", s)
+		}
+		if squares[i], err = SquareAt(n); err != nil {
+			return nil, err
+		}
+	}
+	hops := make([]Move, len(squares)-1)
+	for i := range hops {
+		hops[i] = Move{From: squares[i], To: squares[i+1]}
+	}
+	return hops, nil
+}
+
+// directions a piece may move in: men only forward, kings both ways
+func directions(p Piece) [][2]int {
+	if p.King {
+		return [][2]int{{-1, -1}, {-1, 1}, {1, -1}, {1, 1}}
+	}
+	if p.Side == Black {
+		return [][2]int{{1, -1}, {1, 1}}
+	}
+	return [][2]int{{-1, -1}, {-1, 1}}
+}
+
+// pieceMoves lists the jumps the piece on from can make, or its steps if
+// steps is set
+func (s State) pieceMoves(from Pos, steps bool) []Move {
+	p := s.Board[from.Row][from.Col]
+	var moves []Move
+	for _, d := range directions(p) {
+		over := Pos{Row: from.Row + d[0], Col: from.Col + d[1]}
+		if !over.onBoard() {
+			continue
+		}
+		target := s.Board[over.Row][over.Col]
+		if steps {
+			if target.IsEmpty() {
+				moves = append(moves, Move{From: from, To: over})
+			}
+			continue
+		}
+		to := Pos{Row: from.Row + 2*d[0], Col: from.Col + 2*d[1]}
+		if !target.IsEmpty() && target.Side != p.Side && to.onBoard() && s.Board[to.Row][to.Col].IsEmpty() {
+			moves = append(moves, Move{From: from, To: to})
+		}
+	}
+	return moves
+}
+
+// LegalMoves lists every move the side to move may make. Jumps are
+// compulsory, so if there are any only jumps are listed.
+func (s State) LegalMoves() []Move {
+	if s.Status != "playing" {
+		return nil
+	}
+	if s.Chain != nil {
+		return s.pieceMoves(*s.Chain, false)
+	}
+	var jumps, steps []Move
+	for r := 0; r < 8; r++ {
+		for c := 0; c < 8; c++ {
+			if s.Board[r][c].Side != s.Turn {
+				continue
+			}
+			from := Pos{Row: r, Col: c}
+			jumps = append(jumps, s.pieceMoves(from, false)...)
+			if len(jumps) == 0 {
+				steps = append(steps, s.pieceMoves(from, true)...)
+			}
+		}
+	}
+	if len(jumps) > 0 {
+		return jumps
+	}
+	return steps
+}
+
+// MustJump reports whether the side to move has a capture to make
+func (s State) MustJump() bool {
+	moves := s.LegalMoves()
+	return len(moves) > 0 && moves[0].Jump()
+}
+
+// Targets are the squares the piece on from may move to
+func (s State) Targets(from Pos) map[Pos]bool {
+	targets := make(map[Pos]bool)
+	for _, m := range s.LegalMoves() {
+		if m.From == from {
+			targets[m.To] = true
+		}
+	}
+	return targets
+}
+
+// Legal reports whether m is one of LegalMoves
+func (s State) Legal(m Move) bool {
+	for _, legal := range s.LegalMoves() {
+		if legal == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Play makes m, which must be Legal, and returns the new state. s itself
+// is left alone.
+func Play(s State, m Move) State {
+	p := s.Board[m.From.Row][m.From.Col]
+	man := !p.King
+	s.Moves = append([]string(nil), s.Moves...)
+	s.Seen = append([]string(nil), s.Seen...)
+
+	s.Board[m.From.Row][m.From.Col] = Piece{}
+	if m.Jump() {
+		s.Board[(m.From.Row+m.To.Row)/2][(m.From.Col+m.To.Col)/2] = Piece{}
+	}
+	crowned := man && (m.To.Row == 0 || m.To.Row == 7)
+	if crowned {
+		p.King = true
+	}
+	s.Board[m.To.Row][m.To.Col] = p
+
+	if s.Chain != nil {
+		s.Moves[len(s.Moves)-1] += "x" + strconv.Itoa(m.To.Number())
+	} else {
+		s.Moves = append(s.Moves, m.String())
+	}
+
+	// The same piece keeps jumping while it can, unless it was just crowned
+	s.Chain = nil
+	if m.Jump() && !crowned {
+		s.Chain = &m.To
+		if len(s.pieceMoves(m.To, false)) > 0 {
+			return s
+		}
+		s.Chain = nil
+	}
+
+	// Captures and man moves can't be undone, so nothing before them repeats
+	if m.Jump() || man {
+		s.Quiet = 0
+		s.Seen = nil
+	} else {
+		s.Quiet++
+	}
+	mover := s.Turn
+	s.Turn = opponent(s.Turn)
+	key := s.key()
+	s.Seen = append(s.Seen, key)
+
+	switch {
+	case len(s.LegalMoves()) == 0:
+		s.Status, s.Winner, s.Reason = "finished", mover, ReasonNoMoves
+	case s.repeats(key) >= 3:
+		s.Status, s.Reason = "finished", ReasonRepetition
+	case s.Quiet >= quietLimit:
+		s.Status, s.Reason = "finished", ReasonFortyMoves
+	}
+	return s
+}
+
+func opponent(side string) string {
+	if side == Black {
+		return White
+	}
+	return Black
+}
+
+// key sums up the position and side to move, for spotting repetitions
+func (s State) key() string {
+	var b strings.Builder
+	for r := 0; r < 8; r++ {
+		for c := 0; c < 8; c++ {
+			p := s.Board[r][c]
+			switch {
+			case p.IsEmpty():
+				b.WriteByte('.')
+			case p.King:
+				b.WriteString(p.Side)
+			default:
+				b.WriteString(strings.ToLower(p.Side))
+			}
+		}
+	}
+	b.WriteString(s.Turn)
+	return b.String()
+}
+
+func (s State) repeats(key string) int {
+	n := 0
+	for _, k := range s.Seen {
+		if k == key {
+			n++
+		}
+	}
+	return n
+}
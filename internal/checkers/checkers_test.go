@@ -0,0 +1,183 @@
+package checkers
+
+import (
+	"slices"
+	"testing"
+)
+
+// piece is one piece to set up on a square, by its number
+type piece struct {
+	square int
+	side   string
+	king   bool
+}
+
+// position is an empty board with pieces and turn to move
+func position(t *testing.T, turn string, pieces ...piece) State {
+	t.Helper()
+	s := State{Turn: turn, Status: "playing"}
+	for _, p := range pieces {
+		pos, err := SquareAt(p.square)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Board[pos.Row][pos.Col] = Piece{Side: p.side, King: p.king}
+	}
+	return s
+}
+
+func TestSquareNumbers(t *testing.T) {
+	for n := 1; n <= 32; n++ {
+		p, err := SquareAt(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.Dark() || p.Number() != n {
+			t.Errorf("SquareAt(%d) = %+v, dark %v, numbered %d", n, p, p.Dark(), p.Number())
+		}
+	}
+	for _, n := range []int{0, 33} {
+		if _, err := SquareAt(n); err == nil {
+			t.Errorf("SquareAt(%d) took it", n)
+		}
+	}
+}
+
+func TestLegalMoves(t *testing.T) {
+	tests := []struct {
+		name string
+		s    State
+		want []string
+	}{
+		{"opening", New(Black), []string{"10-14", "10-15", "11-15", "11-16", "12-16", "9-13", "9-14"}},
+		{"capture is forced", position(t, Black,
+			piece{15, Black, false}, piece{19, White, false}, piece{9, Black, false},
+		), []string{"15x24"}},
+		{"men don't jump backwards", position(t, White,
+			piece{19, White, false}, piece{23, Black, false}, piece{32, White, false},
+		), []string{"19-15", "19-16", "32-27", "32-28"}},
+		{"kings jump backwards", position(t, White,
+			piece{19, White, true}, piece{23, Black, false},
+		), []string{"19x26"}},
+		{"every capture is offered", position(t, Black,
+			piece{14, Black, false}, piece{18, White, false}, piece{17, White, false},
+		), []string{"14x21", "14x23"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, m := range tt.s.LegalMoves() {
+				got = append(got, m.String())
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("LegalMoves = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	// Black's man on 15 can take 19 and then 27
+	double := position(t, Black,
+		piece{15, Black, false}, piece{19, White, false}, piece{27, White, false}, piece{1, White, false},
+	)
+	// Black's man on 22 takes 26 and lands on the far row, which ends the
+	// turn even though a king could jump on
+	crowning := position(t, Black,
+		piece{22, Black, false}, piece{26, White, false}, piece{27, White, false}, piece{1, White, false},
+	)
+	tests := []struct {
+		name string
+		s    State
+		seat string
+		move string
+		ok   bool
+	}{
+		{"step", New(Black), Black, "11-15", true},
+		{"out of turn", New(Black), White, "22-18", false},
+		{"someone else's piece", New(Black), Black, "22-18", false},
+		{"men only go forward", position(t, Black, piece{15, Black, false}), Black, "15-11", false},
+		{"garbled", New(Black), Black, "11+15", false},
+		{"step when a capture is due", double, Black, "1-6", false},
+		{"whole multi-jump", double, Black, "15x24x31", true},
+		{"multi-jump written with dashes", double, Black, "15-24-31", true},
+		{"stopping halfway", double, Black, "15x24", false},
+		{"jumping on after the turn is over", crowning, Black, "22x31x24", false},
+		{"crowning ends the jump", crowning, Black, "22x31", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.Check(tt.seat, tt.move); (err == nil) != tt.ok {
+				t.Errorf("Check(%s, %q) = %v, want ok %v", tt.seat, tt.move, err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestApplyMultiJump(t *testing.T) {
+	s := position(t, Black,
+		piece{15, Black, false}, piece{19, White, false}, piece{27, White, false}, piece{1, White, false},
+	)
+	s = s.Apply(Black, "15x24x31").(State)
+
+	want := position(t, White, piece{31, Black, true}, piece{1, White, false})
+	if s.Board != want.Board {
+		t.Errorf("board after 15x24x31 has the wrong pieces")
+	}
+	if s.Turn != White || s.Chain != nil {
+		t.Errorf("turn %s, chain %v after the whole jump, want White and none", s.Turn, s.Chain)
+	}
+	if !slices.Equal(s.Moves, []string{"15x24x31"}) {
+		t.Errorf("Moves = %v, want one move 15x24x31", s.Moves)
+	}
+}
+
+func TestGameEnds(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      State
+		move   string
+		winner string
+		reason string
+	}{
+		{"last piece taken", position(t, Black, piece{15, Black, false}, piece{19, White, false}), "15x24", Black, ReasonNoMoves},
+		{"opponent blocked", position(t, Black,
+			piece{1, Black, false}, piece{22, Black, false}, piece{25, Black, false}, piece{29, White, false},
+		), "1-5", Black, ReasonNoMoves},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.Check(tt.s.Turn, tt.move); err != nil {
+				t.Fatal(err)
+			}
+			out := tt.s.Apply(tt.s.Turn, tt.move).Outcome()
+			if !out.Over || out.Winner != tt.winner || out.Reason != tt.reason {
+				t.Errorf("Outcome = %+v, want %s winning by %s", out, tt.winner, tt.reason)
+			}
+		})
+	}
+}
+
+func TestRepetitionDraws(t *testing.T) {
+	s := position(t, Black, piece{1, Black, true}, piece{32, White, true})
+	s.Seen = []string{s.key()}
+	for _, mv := range []string{"1-5", "32-28", "5-1", "28-32", "1-5", "32-28", "5-1", "28-32"} {
+		if err := s.Check(s.Turn, mv); err != nil {
+			t.Fatalf("%s: %v", mv, err)
+		}
+		s = Play(s, mustParse(t, mv))
+	}
+	if s.Status != "finished" || s.Reason != ReasonRepetition {
+		t.Errorf("status %s (%s), want a draw by repetition", s.Status, s.Reason)
+	}
+}
+
+func mustParse(t *testing.T, move string) Move {
+	t.Helper()
+	hops, err := ParseMoves(move)
+	if err != nil || len(hops) != 1 {
+		t.Fatalf("ParseMoves(%q) = %v, %v", move, hops, err)
+	}
+	return hops[0]
+}
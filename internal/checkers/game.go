@@ -0,0 +1,98 @@
+package checkers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Game is checkers. The host (X) plays Black and the guest (O) White. Moves
+// are whole turns in square numbers, e.g. "11-15" or "15x24x31".
+var Game = &game.Game{
+	ID:        "checkers",
+	Name:      "Checkers",
+	Seats:     [2]string{"Black", "White"},
+	Rules:     rules{},
+	NewScreen: newScreen,
+}
+
+type rules struct{}
+
+func (rules) New(variant any) (game.State, error) {
+	return New(Black), nil
+}
+
+func (rules) Decode(data []byte) (game.State, error) {
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if s.Turn != Black && s.Turn != White {
+		return nil, fmt.Errorf("stored game has no side to move")
+	}
+	return s, nil
+}
+
+func (s State) ToMove() string {
+	return s.Turn
+}
+
+func (s State) Check(seat, move string) error {
+	if s.Status != "playing" {
+		return fmt.Errorf("game is over")
+	}
+	if seat != s.Turn {
+		return game.ErrNotYourTurn
+	}
+	hops, err := ParseMoves(move)
+	if err != nil {
+		return err
+	}
+	for i, m := range hops {
+		if i > 0 && s.Chain == nil {
+			return fmt.Errorf("%s goes on after the turn is over", move)
+		}
+		if err := s.checkHop(seat, m); err != nil {
+			return err
+		}
+		s = Play(s, m)
+	}
+	if s.Chain != nil {
+		return fmt.Errorf("keep jumping with the piece on %d", s.Chain.Number())
+	}
+	return nil
+}
+
+// checkHop says why m, one hop of seat's turn, can't be made
+func (s State) checkHop(seat string, m Move) error {
+	if s.Board[m.From.Row][m.From.Col].Side != seat {
+		return fmt.Errorf("no piece of yours on %d", m.From.Number())
+	}
+	if s.Legal(m) {
+		return nil
+	}
+	switch {
+	case s.Chain != nil && m.From != *s.Chain:
+		return fmt.Errorf("keep jumping with the piece on %d", s.Chain.Number())
+	case !m.Jump() && s.MustJump():
+		return fmt.Errorf("you must capture")
+	}
+	return fmt.Errorf("%s is not a legal move", m)
+}
+
+func (s State) Apply(seat, move string) game.State {
+	hops, _ := ParseMoves(move)
+	for _, m := range hops {
+		s = Play(s, m)
+	}
+	return s
+}
+
+func (s State) Outcome() game.Outcome {
+	return game.Outcome{Over: s.Status != "playing", Winner: s.Winner, Reason: s.Reason}
+}
+
+func (s State) Rematch(first string) game.State {
+	return New(first)
+}
@@ -0,0 +1,239 @@
+package checkers
+
+import (
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// screen is the board cursor, the selected piece and where it may go
+type screen struct {
+	synced bool
+	r, c   int
+
+	selected   bool
+	sel        Pos
+	validMoves map[Pos]bool
+
+	// hops are a multi-jump made so far and mid the board after them. The
+	// turn is sent as one move once the piece can't jump again.
+	hops []Move
+	mid  State
+}
+
+func newScreen() game.Screen {
+	return screen{validMoves: make(map[Pos]bool)}
+}
+
+// Sync puts the cursor on the player's own front row when the first
+// snapshot arrives. In the middle of a multi-jump the jumping piece stays
+// picked up, so the next landing square is all that is left to choose.
+func (sc screen) Sync(v game.View) game.Screen {
+	s := v.State.(State)
+	if len(sc.hops) > 0 {
+		if v.MyTurn() {
+			return sc
+		}
+		sc.hops = nil
+	}
+	if !sc.synced {
+		sc.synced = true
+		sc.r, sc.c = 5, 4
+		if v.Seat == Black {
+			sc.r, sc.c = 2, 3
+		}
+	}
+	switch {
+	case s.Chain != nil && v.MyTurn():
+		sc.selected, sc.sel = true, *s.Chain
+		sc.r, sc.c = s.Chain.Row, s.Chain.Col
+		sc.validMoves = s.Targets(*s.Chain)
+	case !v.MyTurn():
+		sc = sc.deselect()
+	}
+	return sc
+}
+
+func (sc screen) deselect() screen {
+	sc.selected = false
+	sc.validMoves = make(map[Pos]bool)
+	return sc
+}
+
+// board is the game as the player sees it, partway through their
+// multi-jump if they are making one
+func (sc screen) board(v game.View) State {
+	if len(sc.hops) > 0 {
+		return sc.mid
+	}
+	return v.State.(State)
+}
+
+func (sc screen) Update(v game.View, msg tea.KeyMsg) (game.Screen, game.Action) {
+	s := sc.board(v)
+
+	// Black sees the board from their side, so the arrows flip too
+	step := 1
+	if v.Seat == Black {
+		step = -1
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		sc.r = clampSquare(sc.r - step)
+	case "down", "j":
+		sc.r = clampSquare(sc.r + step)
+	case "left", "h":
+		sc.c = clampSquare(sc.c - step)
+	case "right", "l":
+		sc.c = clampSquare(sc.c + step)
+	case "esc":
+		if !sc.selected {
+			return sc, game.Action{Unhandled: true}
+		}
+		// A multi-jump can't be put down halfway, only taken back
+		if len(sc.hops) > 0 {
+			sc.sel, sc.hops = sc.hops[0].From, nil
+			sc.validMoves = v.State.(State).Targets(sc.sel)
+		} else if s.Chain == nil {
+			sc = sc.deselect()
+		}
+	case "enter", " ":
+		if !v.MyTurn() {
+			return sc, game.Action{}
+		}
+		return sc.choose(s, v.Seat)
+	default:
+		return sc, game.Action{Unhandled: true}
+	}
+	return sc, game.Action{}
+}
+
+func clampSquare(i int) int {
+	return min(max(i, 0), 7)
+}
+
+// choose picks up the piece under the cursor, or moves the one picked up
+// there
+func (sc screen) choose(s State, side string) (game.Screen, game.Action) {
+	cursor := Pos{Row: sc.r, Col: sc.c}
+	if sc.selected {
+		if sc.validMoves[cursor] {
+			hops := append(append([]Move(nil), sc.hops...), Move{From: sc.sel, To: cursor})
+			if next := Play(s, hops[len(hops)-1]); next.Chain != nil {
+				sc.hops, sc.mid = hops, next
+				sc.sel, sc.validMoves = cursor, next.Targets(cursor)
+				return sc, game.Action{}
+			}
+			// The server validates and applies the move
+			sc.hops = nil
+			return sc.deselect(), game.Action{Move: JoinMoves(hops)}
+		}
+		if s.Chain != nil {
+			return sc, game.Action{}
+		}
+		// Choosing the selected piece again puts it down
+		if cursor == sc.sel {
+			return sc.deselect(), game.Action{}
+		}
+	}
+
+	// Pick up a piece of our own, or drop the selection
+	if s.Board[sc.r][sc.c].Side != side {
+		return sc.deselect(), game.Action{}
+	}
+	sc.selected = true
+	sc.sel = cursor
+	sc.validMoves = s.Targets(cursor)
+	return sc, game.Action{}
+}
+
+func (sc screen) Help(v game.View) string {
+	if len(sc.hops) > 0 {
+		return "arrows/hjkl move • enter/space jump • esc take back • q quit"
+	}
+	return "arrows/hjkl move • enter/space select • esc deselect • r restart • q quit"
+}
+
+func (sc screen) Render(v game.View) string {
+	s := sc.board(v)
+	sqW, sqH := game.SquareSize(8, v.Width, v.Height)
+
+	isFlipped := v.Seat == Black
+
+	files := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	ranks := []string{"8", "7", "6", "5", "4", "3", "2", "1"}
+	if isFlipped {
+		files = []string{"h", "g", "f", "e", "d", "c", "b", "a"}
+		ranks = []string{"1", "2", "3", "4", "5", "6", "7", "8"}
+	}
+
+	grid := game.LabeledGrid(files, ranks, sqW, sqH, func(r, c int) string {
+		br, bc := r, c
+		if isFlipped {
+			br = 7 - r
+			bc = 7 - c
+		}
+		pos := Pos{Row: br, Col: bc}
+
+		bg := styles.ChessLightSquare
+		if pos.Dark() {
+			bg = styles.ChessDarkSquare
+		}
+		isSelected := sc.selected && sc.sel == pos
+		if isSelected {
+			bg = styles.ChessSelected
+		} else if sc.validMoves[pos] {
+			bg = styles.ChessHighlight
+		}
+		if sc.r == br && sc.c == bc && !isSelected {
+			bg = game.CursorSquare
+		}
+
+		piece := s.Board[br][bc]
+		fg := styles.CheckersBlackPiece
+		if piece.Side == White {
+			fg = styles.CheckersWhitePiece
+		}
+		return game.Square(pieceSymbol(piece), fg, bg, sqW, sqH)
+	})
+
+	return game.BoardFrame(lipgloss.JoinVertical(lipgloss.Center,
+		styles.Title.Render("CHECKERS"),
+		game.Scoreboard(v),
+		"",
+		grid,
+		"",
+		renderStatus(v, s),
+	))
+}
+
+// renderStatus is the usual status line, with a reminder when a capture
+// is due, how the game ended, and the last move
+func renderStatus(v game.View, s State) string {
+	lines := []string{game.StatusLine(v, v.Game.SeatName(s.Turn))}
+	switch {
+	case v.Info.Status == "finished" && v.Info.Reason != "":
+		lines = append(lines, styles.Subtle.Render("("+v.Info.Reason+")"))
+	case v.MyTurn() && s.Chain != nil:
+		lines = append(lines, styles.Highlight.Render("Keep jumping!"))
+	case v.MyTurn() && s.MustJump():
+		lines = append(lines, styles.Highlight.Render("You must capture"))
+	}
+	if len(s.Moves) > 0 {
+		lines = append(lines, styles.Subtle.Render("Last move: "+s.Moves[len(s.Moves)-1]))
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, lines...)
+}
+
+func pieceSymbol(p Piece) string {
+	switch {
+	case p.IsEmpty():
+		return ""
+	case p.King:
+		return "♛"
+	}
+	return "●"
+}
@@ -3,6 +3,7 @@ package chess
 import (
 	"crypto/sha256"
 	"fmt"
+	"strings"
 )
 
 type Piece struct {
@@ -37,11 +38,23 @@ type GameState struct {
 	EnPassantTarget *Pos           `json:"enPassantTarget"` // Square behind pawn moved 2 steps
 	HalfMoveClock   int            `json:"halfMoveClock"`   // For 50-move rule
 	FullMoveNumber  int            `json:"fullMoveNumber"`
-	History         map[string]int `json:"history"` // For 3-fold repetition
-	Status          string         `json:"status"`  // "playing", "checkmate", "stalemate", "draw"
-	Winner          string         `json:"winner"`  // "White", "Black", "Draw", ""
+	History         map[string]int `json:"history"`            // For 3-fold repetition
+	Status          string         `json:"status"`             // "playing", "checkmate", "stalemate", "draw"
+	Winner          string         `json:"winner"`             // "White", "Black", "Draw", ""
+	StartFEN        string         `json:"startFen,omitempty"` // Set when the game began from a custom position
+	Moves           []string       `json:"moves,omitempty"`    // Every move so far in SAN
+	Reason          string         `json:"reason,omitempty"`   // Why the game ended, one of the Reason constants
 }
 
+// Reasons a game can end, stored in GameState.Reason
+const (
+	ReasonCheckmate    = "checkmate"
+	ReasonStalemate    = "stalemate"
+	ReasonFiftyMove    = "50-move rule"
+	ReasonRepetition   = "threefold repetition"
+	ReasonInsufficient = "insufficient material"
+)
+
 // StartingBoard: rows 0-7 map to ranks 8-1
 var StartingBoard = [8][8]Piece{
 	{{"R", false, false}, {"N", false, false}, {"B", false, false}, {"Q", false, false}, {"K", false, false}, {"B", false, false}, {"N", false, false}, {"R", false, false}},
@@ -55,7 +68,7 @@ var StartingBoard = [8][8]Piece{
 }
 
 func NewGame() GameState {
-	return GameState{
+	state := GameState{
 		Board:           StartingBoard,
 		Turn:            "White",
 		EnPassantTarget: nil,
@@ -64,6 +77,22 @@ func NewGame() GameState {
 		History:         make(map[string]int),
 		Status:          "playing",
 	}
+	recordPosition(&state)
+	return state
+}
+
+// recordPosition counts the current position towards threefold repetition
+// and returns its key. Positions repeat when pieces, side to move, castling
+// rights and en passant all match, which is exactly the first four FEN
+// fields. The key is hashed since Firebase doesn't allow "/" in keys.
+func recordPosition(state *GameState) string {
+	raw := strings.Join(strings.Fields(ToFEN(*state))[:4], " ")
+	key := fmt.Sprintf("%x", sha256.Sum256([]byte(raw)))
+	if state.History == nil {
+		state.History = make(map[string]int)
+	}
+	state.History[key]++
+	return key
 }
 
 func inBounds(r, c int) bool {
@@ -380,6 +409,41 @@ func GetLegalMoves(state GameState, r, c int) map[Pos]bool {
 
 // ApplyMove updates the game state with the move
 func ApplyMove(state GameState, from, to Pos, promotionType string) GameState {
+	san := moveSAN(state, from, to, promotionType)
+	movePieces(&state, from, to, promotionType)
+
+	// Check Game End Conditions
+	updateStatus(&state)
+
+	// Record the move. The capped slice makes append copy, so states
+	// handed out earlier keep their own move list.
+	if state.Reason == ReasonCheckmate {
+		san += "#"
+	} else if IsInCheck(state.Board, state.Turn == "White") {
+		san += "+"
+	}
+	state.Moves = append(state.Moves[:len(state.Moves):len(state.Moves)], san)
+
+	// 3-fold repetition. The history is copied first, like the move list,
+	// so the state passed in is left as it was.
+	history := make(map[string]int, len(state.History)+1)
+	for k, v := range state.History {
+		history[k] = v
+	}
+	state.History = history
+	key := recordPosition(&state)
+	if state.History[key] >= 3 && state.Status == "playing" {
+		state.Status = "finished"
+		state.Winner = "Draw"
+		state.Reason = ReasonRepetition
+	}
+
+	return state
+}
+
+// movePieces makes the move on the board and moves the clocks and turn
+// on, without recording it or checking whether the game is over
+func movePieces(state *GameState, from, to Pos, promotionType string) {
 	piece := state.Board[from.Row][from.Col]
 	target := state.Board[to.Row][to.Col]
 
@@ -441,65 +505,59 @@ func ApplyMove(state GameState, from, to Pos, promotionType string) GameState {
 	} else {
 		state.Turn = "Black"
 	}
+}
 
-	// 7. Check Game End Conditions
-	state.Status = "playing"
-
-	// Has legal moves?
-	hasMoves := false
+// hasLegalMove reports whether the side to move has any legal move
+func hasLegalMove(state GameState) bool {
 	for r := 0; r < 8; r++ {
 		for c := 0; c < 8; c++ {
 			if state.Board[r][c].IsWhite == (state.Turn == "White") {
 				moves := GetLegalMoves(state, r, c)
 				if len(moves) > 0 {
-					hasMoves = true
-					break
+					return true
 				}
 			}
 		}
-		if hasMoves {
-			break
-		}
 	}
+	return false
+}
+
+// updateStatus checks mate, stalemate, the 50-move rule and insufficient
+// material for the side to move. Repetition is tracked by ApplyMove.
+func updateStatus(state *GameState) {
+	state.Status = "playing"
+	state.Reason = ""
 
-	if !hasMoves {
+	if !hasLegalMove(*state) {
 		if IsInCheck(state.Board, state.Turn == "White") {
 			state.Status = "finished"
 			state.Winner = "Black"
 			if state.Turn == "Black" {
 				state.Winner = "White"
 			}
-		} else {
-			state.Status = "finished"
-			state.Winner = "Draw" // Stalemate
+			state.Reason = ReasonCheckmate
+			return
 		}
+		state.Status = "finished"
+		state.Winner = "Draw"
+		state.Reason = ReasonStalemate
+		return
 	}
 
 	// 50 Move Rule
 	if state.HalfMoveClock >= 100 {
 		state.Status = "finished"
 		state.Winner = "Draw"
+		state.Reason = ReasonFiftyMove
+		return
 	}
 
 	// Insufficient Material
 	if IsInsufficientMaterial(state.Board) {
 		state.Status = "finished"
 		state.Winner = "Draw"
+		state.Reason = ReasonInsufficient
 	}
-
-	// 3-fold repetition
-	raw := fmt.Sprintf("%v-%s-%v", state.Board, state.Turn, state.EnPassantTarget)
-	key := fmt.Sprintf("%x", sha256.Sum256([]byte(raw)))
-	if state.History == nil {
-		state.History = make(map[string]int)
-	}
-	state.History[key]++
-	if state.History[key] >= 3 {
-		state.Status = "finished"
-		state.Winner = "Draw"
-	}
-
-	return state
 }
 
 func IsInsufficientMaterial(board [8][8]Piece) bool {
@@ -0,0 +1,225 @@
+package chess
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/ai"
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Levels are the strengths offered for chess, weakest first
+var Levels = []ai.Level{
+	{Name: "Easy", Depth: 1, Budget: time.Second, Noise: 150},
+	{Name: "Medium", Depth: 2, Budget: 2 * time.Second, Noise: 30},
+	{Name: "Hard", Depth: 3, Budget: 3 * time.Second},
+	{Name: "Expert", Depth: 5, Budget: 6 * time.Second},
+}
+
+// engine plays the computer's side in solo games
+type engine struct{}
+
+func (engine) Levels() []ai.Level {
+	return Levels
+}
+
+func (engine) Move(ctx context.Context, s game.State, lvl ai.Level) (string, bool) {
+	m, ok := ai.Search[GameState, searchMove](ctx, searcher{}, s.(GameState), lvl)
+	if !ok {
+		return "", false
+	}
+	return FormatMove(m.From, m.To, m.Promotion), true
+}
+
+// searchMove is one move as the search sees it
+type searchMove struct {
+	From, To  Pos
+	Promotion string
+}
+
+// searcher lets the search play chess through GetLegalMoves and the board
+// half of ApplyMove
+type searcher struct{}
+
+var pieceValue = map[string]int{"P": 100, "N": 320, "B": 330, "R": 500, "Q": 900, "K": 0}
+
+func (searcher) Moves(s GameState) []searchMove {
+	if s.Status != "playing" {
+		return nil
+	}
+
+	type scored struct {
+		m     searchMove
+		order int
+	}
+	var list []scored
+	white := s.Turn == "White"
+	for r := 0; r < 8; r++ {
+		for c := 0; c < 8; c++ {
+			p := s.Board[r][c]
+			if p.IsEmpty() || p.IsWhite != white {
+				continue
+			}
+			from := Pos{Row: r, Col: c}
+			for to := range GetLegalMoves(s, r, c) {
+				// Captures first, most valuable victim by cheapest attacker
+				order := 0
+				if victim := s.Board[to.Row][to.Col]; !victim.IsEmpty() {
+					order = 10*pieceValue[victim.Type] - pieceValue[p.Type]
+				}
+				if p.Type == "P" && (to.Row == 0 || to.Row == 7) {
+					// A knight is the only underpromotion that ever beats a queen
+					list = append(list,
+						scored{searchMove{from, to, "Q"}, order + 8000},
+						scored{searchMove{from, to, "N"}, order})
+					continue
+				}
+				list = append(list, scored{searchMove{From: from, To: to}, order})
+			}
+		}
+	}
+
+	// Legal moves come out of a map, so break ties by square to keep the
+	// engine deterministic
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.order != b.order {
+			return a.order > b.order
+		}
+		if a.m.From != b.m.From {
+			return a.m.From.Row*8+a.m.From.Col < b.m.From.Row*8+b.m.From.Col
+		}
+		return a.m.To.Row*8+a.m.To.Col < b.m.To.Row*8+b.m.To.Col
+	})
+
+	moves := make([]searchMove, len(list))
+	for i, sm := range list {
+		moves[i] = sm.m
+	}
+	return moves
+}
+
+// NoisyMoves are captures and promotions, which Moves already lists first
+func (g searcher) NoisyMoves(s GameState) []searchMove {
+	var noisy []searchMove
+	for _, m := range g.Moves(s) {
+		isCapture := !s.Board[m.To.Row][m.To.Col].IsEmpty()
+		if !isCapture && m.Promotion == "" {
+			break
+		}
+		noisy = append(noisy, m)
+	}
+	return noisy
+}
+
+// Play only moves the pieces and looks for the end of the game. Searched
+// lines are never shown, so they go without SAN, and without the history
+// that repetitions are counted in, which would have to be copied for each.
+func (searcher) Play(s GameState, m searchMove) GameState {
+	movePieces(&s, m.From, m.To, m.Promotion)
+	updateStatus(&s)
+	return s
+}
+
+func (searcher) Score(s GameState) (int, bool) {
+	if s.Status != "playing" {
+		switch s.Winner {
+		case "Draw", "":
+			return 0, true
+		case s.Turn:
+			return ai.Win, true
+		}
+		return ai.Loss, true
+	}
+
+	// Material plus piece-square bonuses, from White's side
+	score := 0
+	for r := 0; r < 8; r++ {
+		for c := 0; c < 8; c++ {
+			p := s.Board[r][c]
+			if p.IsEmpty() {
+				continue
+			}
+			row := r
+			if !p.IsWhite {
+				row = 7 - r // Tables are drawn for White
+			}
+			v := pieceValue[p.Type] + pieceSquare[p.Type][row][c]
+			if p.IsWhite {
+				score += v
+			} else {
+				score -= v
+			}
+		}
+	}
+	if s.Turn == "Black" {
+		score = -score
+	}
+	return score, false
+}
+
+// pieceSquare gives each piece a bonus by square, rank 8 first, as seen
+// by White. These are the widely used "simplified evaluation" tables.
+var pieceSquare = map[string][8][8]int{
+	"P": {
+		{0, 0, 0, 0, 0, 0, 0, 0},
+		{50, 50, 50, 50, 50, 50, 50, 50},
+		{10, 10, 20, 30, 30, 20, 10, 10},
+		{5, 5, 10, 25, 25, 10, 5, 5},
+		{0, 0, 0, 20, 20, 0, 0, 0},
+		{5, -5, -10, 0, 0, -10, -5, 5},
+		{5, 10, 10, -20, -20, 10, 10, 5},
+		{0, 0, 0, 0, 0, 0, 0, 0},
+	},
+	"N": {
+		{-50, -40, -30, -30, -30, -30, -40, -50},
+		{-40, -20, 0, 0, 0, 0, -20, -40},
+		{-30, 0, 10, 15, 15, 10, 0, -30},
+		{-30, 5, 15, 20, 20, 15, 5, -30},
+		{-30, 0, 15, 20, 20, 15, 0, -30},
+		{-30, 5, 10, 15, 15, 10, 5, -30},
+		{-40, -20, 0, 5, 5, 0, -20, -40},
+		{-50, -40, -30, -30, -30, -30, -40, -50},
+	},
+	"B": {
+		{-20, -10, -10, -10, -10, -10, -10, -20},
+		{-10, 0, 0, 0, 0, 0, 0, -10},
+		{-10, 0, 5, 10, 10, 5, 0, -10},
+		{-10, 5, 5, 10, 10, 5, 5, -10},
+		{-10, 0, 10, 10, 10, 10, 0, -10},
+		{-10, 10, 10, 10, 10, 10, 10, -10},
+		{-10, 5, 0, 0, 0, 0, 5, -10},
+		{-20, -10, -10, -10, -10, -10, -10, -20},
+	},
+	"R": {
+		{0, 0, 0, 0, 0, 0, 0, 0},
+		{5, 10, 10, 10, 10, 10, 10, 5},
+		{-5, 0, 0, 0, 0, 0, 0, -5},
+		{-5, 0, 0, 0, 0, 0, 0, -5},
+		{-5, 0, 0, 0, 0, 0, 0, -5},
+		{-5, 0, 0, 0, 0, 0, 0, -5},
+		{-5, 0, 0, 0, 0, 0, 0, -5},
+		{0, 0, 0, 5, 5, 0, 0, 0},
+	},
+	"Q": {
+		{-20, -10, -10, -5, -5, -10, -10, -20},
+		{-10, 0, 0, 0, 0, 0, 0, -10},
+		{-10, 0, 5, 5, 5, 5, 0, -10},
+		{-5, 0, 5, 5, 5, 5, 0, -5},
+		{0, 0, 5, 5, 5, 5, 0, -5},
+		{-10, 5, 5, 5, 5, 5, 0, -10},
+		{-10, 0, 5, 0, 0, 0, 0, -10},
+		{-20, -10, -10, -5, -5, -10, -10, -20},
+	},
+	"K": {
+		{-30, -40, -40, -50, -50, -40, -40, -30},
+		{-30, -40, -40, -50, -50, -40, -40, -30},
+		{-30, -40, -40, -50, -50, -40, -40, -30},
+		{-30, -40, -40, -50, -50, -40, -40, -30},
+		{-20, -30, -30, -40, -40, -30, -30, -20},
+		{-10, -20, -20, -20, -20, -20, -20, -10},
+		{20, 20, 0, 0, 0, 0, 20, 20},
+		{20, 30, 10, 0, 0, 10, 30, 20},
+	},
+}
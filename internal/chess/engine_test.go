@@ -0,0 +1,56 @@
+package chess
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/ai"
+)
+
+func TestEngineFinds(t *testing.T) {
+	tests := []struct {
+		name, fen, want string
+	}{
+		{"mate in one", "6k1/5ppp/8/8/8/8/8/R3K3 w - - 0 1", "a1a8"},
+		{"mate in one for black", "r3k3/8/8/8/8/8/5PPP/6K1 b - - 0 1", "a8a1"},
+		{"hanging queen", "4k3/8/8/3q4/8/8/3R4/4K3 w - - 0 1", "d2d5"},
+		{"already mated", "7k/8/8/8/8/8/r7/1r2K3 w - - 0 1", ""},
+		{"promotion", "8/P7/8/8/8/8/8/k3K3 w - - 0 1", "a7a8q"},
+	}
+	lvl := ai.Level{Name: "Test", Depth: 3, Budget: 10 * time.Second}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseFEN(tt.fen)
+			if err != nil {
+				t.Fatal(err)
+			}
+			move, ok := engine{}.Move(context.Background(), s, lvl)
+			if tt.want == "" {
+				if ok {
+					t.Errorf("Move = %q with no moves left", move)
+				}
+				return
+			}
+			if !ok || move != tt.want {
+				t.Errorf("Move = %q, %v, want %q", move, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchPlayMatchesApplyMove(t *testing.T) {
+	s := play(t, StartFEN, "e2e4", "d7d5", "e4d5", "g8f6", "f1b5", "c7c6")
+	before := ToFEN(s)
+	for _, m := range (searcher{}).Moves(s) {
+		got := searcher{}.Play(s, m)
+		want := ApplyMove(s, m.From, m.To, m.Promotion)
+		if ToFEN(got) != ToFEN(want) || got.Status != want.Status || got.Reason != want.Reason {
+			t.Errorf("%s: Play gives %s (%s), ApplyMove %s (%s)",
+				FormatMove(m.From, m.To, m.Promotion), ToFEN(got), got.Reason, ToFEN(want), want.Reason)
+		}
+	}
+	if ToFEN(s) != before || len(s.Moves) != 6 {
+		t.Errorf("searching changed the position it searched from")
+	}
+}
@@ -0,0 +1,219 @@
+package chess
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StartFEN is the standard starting position
+const StartFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+var fenFiles = "abcdefgh"
+
+// SquareName returns algebraic notation for a board position, e.g. "e4"
+func SquareName(p Pos) string {
+	return fmt.Sprintf("%c%d", fenFiles[p.Col], 8-p.Row)
+}
+
+// ParseSquare is the inverse of SquareName
+func ParseSquare(s string) (Pos, error) {
+	if len(s) != 2 || s[0] < 'a' || s[0] > 'h' || s[1] < '1' || s[1] > '8' {
+		return Pos{}, fmt.Errorf("bad square %q", s)
+	}
+	return Pos{Row: 8 - int(s[1]-'0'), Col: int(s[0] - 'a')}, nil
+}
+
+// ParseFEN builds a GameState from a FEN string. The last two fields
+// (half-move clock and full-move number) may be left out.
+func ParseFEN(fen string) (GameState, error) {
+	fields := strings.Fields(fen)
+	if len(fields) == 4 {
+		fields = append(fields, "0", "1")
+	}
+	if len(fields) != 6 {
+		return GameState{}, fmt.Errorf("FEN needs 6 fields, got %d", len(fields))
+	}
+
+	state := GameState{
+		History: make(map[string]int),
+		Status:  "playing",
+	}
+
+	// 1. Pieces, rank 8 first
+	ranks := strings.Split(fields[0], "/")
+	if len(ranks) != 8 {
+		return GameState{}, fmt.Errorf("FEN needs 8 ranks, got %d", len(ranks))
+	}
+	kings := map[bool]int{}
+	for r, rank := range ranks {
+		c := 0
+		for _, ch := range rank {
+			if ch >= '1' && ch <= '8' {
+				c += int(ch - '0')
+				continue
+			}
+			t := strings.ToUpper(string(ch))
+			if !strings.Contains("KQRBNP", t) {
+				return GameState{}, fmt.Errorf("bad piece %q in rank %d", ch, 8-r)
+			}
+			if c >= 8 {
+				return GameState{}, fmt.Errorf("rank %d has more than 8 squares", 8-r)
+			}
+			isWhite := t == string(ch)
+			if t == "P" && (r == 0 || r == 7) {
+				return GameState{}, fmt.Errorf("pawn on rank %d", 8-r)
+			}
+			if t == "K" {
+				kings[isWhite]++
+			}
+			// Everything counts as moved until the castling field says otherwise
+			state.Board[r][c] = Piece{Type: t, IsWhite: isWhite, HasMoved: t != "P"}
+			c++
+		}
+		if c != 8 {
+			return GameState{}, fmt.Errorf("rank %d does not add up to 8 squares", 8-r)
+		}
+	}
+	if kings[true] != 1 || kings[false] != 1 {
+		return GameState{}, fmt.Errorf("each side needs exactly one king")
+	}
+
+	// 2. Side to move
+	switch fields[1] {
+	case "w":
+		state.Turn = "White"
+	case "b":
+		state.Turn = "Black"
+	default:
+		return GameState{}, fmt.Errorf("side to move must be w or b, got %q", fields[1])
+	}
+
+	// 3. Castling rights live on the pieces as HasMoved
+	if fields[2] != "-" {
+		for _, ch := range fields[2] {
+			row, rookCol := 7, 7
+			switch ch {
+			case 'K':
+			case 'Q':
+				rookCol = 0
+			case 'k':
+				row = 0
+			case 'q':
+				row, rookCol = 0, 0
+			default:
+				return GameState{}, fmt.Errorf("bad castling flag %q", ch)
+			}
+			king, rook := state.Board[row][4], state.Board[row][rookCol]
+			isWhite := row == 7
+			if king.Type != "K" || king.IsWhite != isWhite || rook.Type != "R" || rook.IsWhite != isWhite {
+				return GameState{}, fmt.Errorf("castling flag %q without king and rook at home", ch)
+			}
+			state.Board[row][4].HasMoved = false
+			state.Board[row][rookCol].HasMoved = false
+		}
+	}
+
+	// 4. En passant target
+	if fields[3] != "-" {
+		ep, err := ParseSquare(fields[3])
+		if err != nil {
+			return GameState{}, err
+		}
+		if (state.Turn == "White" && ep.Row != 2) || (state.Turn == "Black" && ep.Row != 5) {
+			return GameState{}, fmt.Errorf("en passant square %s is on the wrong rank", fields[3])
+		}
+		state.EnPassantTarget = &ep
+	}
+
+	// 5./6. Clocks
+	var err error
+	if state.HalfMoveClock, err = strconv.Atoi(fields[4]); err != nil || state.HalfMoveClock < 0 {
+		return GameState{}, fmt.Errorf("bad half-move clock %q", fields[4])
+	}
+	if state.FullMoveNumber, err = strconv.Atoi(fields[5]); err != nil || state.FullMoveNumber < 1 {
+		return GameState{}, fmt.Errorf("bad full-move number %q", fields[5])
+	}
+
+	// The side that just moved can't have left its king hanging
+	if IsInCheck(state.Board, state.Turn != "White") {
+		return GameState{}, fmt.Errorf("side not to move is in check")
+	}
+
+	state.StartFEN = ToFEN(state)
+	recordPosition(&state)
+	updateStatus(&state)
+	return state, nil
+}
+
+// ToFEN serializes the position, castling rights, en passant target and
+// clocks of a GameState.
+func ToFEN(state GameState) string {
+	var sb strings.Builder
+
+	for r := 0; r < 8; r++ {
+		empty := 0
+		for c := 0; c < 8; c++ {
+			p := state.Board[r][c]
+			if p.IsEmpty() {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				sb.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			t := p.Type
+			if !p.IsWhite {
+				t = strings.ToLower(t)
+			}
+			sb.WriteString(t)
+		}
+		if empty > 0 {
+			sb.WriteString(strconv.Itoa(empty))
+		}
+		if r < 7 {
+			sb.WriteByte('/')
+		}
+	}
+
+	side := "w"
+	if state.Turn == "Black" {
+		side = "b"
+	}
+
+	castling := ""
+	canCastle := func(row, rookCol int) bool {
+		isWhite := row == 7
+		k, rk := state.Board[row][4], state.Board[row][rookCol]
+		return k.Type == "K" && k.IsWhite == isWhite && !k.HasMoved &&
+			rk.Type == "R" && rk.IsWhite == isWhite && !rk.HasMoved
+	}
+	if canCastle(7, 7) {
+		castling += "K"
+	}
+	if canCastle(7, 0) {
+		castling += "Q"
+	}
+	if canCastle(0, 7) {
+		castling += "k"
+	}
+	if canCastle(0, 0) {
+		castling += "q"
+	}
+	if castling == "" {
+		castling = "-"
+	}
+
+	ep := "-"
+	if state.EnPassantTarget != nil {
+		ep = SquareName(*state.EnPassantTarget)
+	}
+
+	fullMove := state.FullMoveNumber
+	if fullMove < 1 {
+		fullMove = 1
+	}
+
+	return fmt.Sprintf("%s %s %s %s %d %d", sb.String(), side, castling, ep, state.HalfMoveClock, fullMove)
+}
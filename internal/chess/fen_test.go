@@ -0,0 +1,90 @@
+package chess
+
+import "testing"
+
+func TestFENRoundTrip(t *testing.T) {
+	tests := []struct {
+		name, fen string
+	}{
+		{"start", StartFEN},
+		{"after 1. e4", "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"},
+		{"some castling rights", "r3k2r/8/8/8/8/8/8/R3K2R w Kq - 4 20"},
+		{"no castling", "4k3/8/8/8/8/8/8/4K2R b - - 12 40"},
+		{"black en passant", "4k3/8/8/8/3pP3/8/8/4K3 b - e3 0 30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseFEN(tt.fen)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ToFEN(s); got != tt.fen {
+				t.Errorf("ToFEN = %q, want %q", got, tt.fen)
+			}
+		})
+	}
+}
+
+func TestFENStartMatchesNewGame(t *testing.T) {
+	if got := ToFEN(NewGame()); got != StartFEN {
+		t.Errorf("ToFEN(NewGame()) = %q, want %q", got, StartFEN)
+	}
+}
+
+func TestFENShortFormDefaultsClocks(t *testing.T) {
+	s, err := ParseFEN("4k3/8/8/8/8/8/8/4K3 w - -")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.HalfMoveClock != 0 || s.FullMoveNumber != 1 {
+		t.Errorf("clocks = %d, %d, want 0, 1", s.HalfMoveClock, s.FullMoveNumber)
+	}
+}
+
+func TestFENRejects(t *testing.T) {
+	tests := []struct {
+		name, fen string
+	}{
+		{"too few fields", "8/8/8/8/8/8/8/8 w"},
+		{"seven ranks", "4k3/8/8/8/8/8/4K3 w - - 0 1"},
+		{"rank too long", "4k3/9/8/8/8/8/8/4K3 w - - 0 1"},
+		{"bad piece", "4k3/8/8/8/8/8/8/4K2X w - - 0 1"},
+		{"no black king", "8/8/8/8/8/8/8/4K3 w - - 0 1"},
+		{"pawn on the last rank", "P3k3/8/8/8/8/8/8/4K3 w - - 0 1"},
+		{"bad side", "4k3/8/8/8/8/8/8/4K3 x - - 0 1"},
+		{"castling without a rook", "4k3/8/8/8/8/8/8/4K3 w K - 0 1"},
+		{"en passant on the wrong rank", "4k3/8/8/8/8/8/8/4K3 w - e3 0 1"},
+		{"bad clock", "4k3/8/8/8/8/8/8/4K3 w - - -1 1"},
+		{"side not to move in check", "4k3/8/8/8/8/8/8/4R1K1 w - - 0 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseFEN(tt.fen); err == nil {
+				t.Errorf("ParseFEN(%q) took it", tt.fen)
+			}
+		})
+	}
+}
+
+func TestFENStatus(t *testing.T) {
+	tests := []struct {
+		name, fen string
+		reason    string
+	}{
+		{"checkmate", "7k/6Q1/6K1/8/8/8/8/8 b - - 0 1", ReasonCheckmate},
+		{"stalemate", "7k/5Q2/6K1/8/8/8/8/8 b - - 0 1", ReasonStalemate},
+		{"bare kings", "7k/8/6K1/8/8/8/8/8 w - - 0 1", ReasonInsufficient},
+		{"playing", StartFEN, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseFEN(tt.fen)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Reason != tt.reason {
+				t.Errorf("Reason = %q, want %q", s.Reason, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package chess
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Game is chess. The host (X) plays White and the guest (O) Black. Moves
+// are in UCI notation, e.g. "e2e4" or "e7e8q".
+var Game = &game.Game{
+	ID:        "chess",
+	Name:      "Chess",
+	Seats:     [2]string{"White", "Black"},
+	Rules:     rules{},
+	NewScreen: newScreen,
+	NewSetup:  newSetup,
+	Engine:    engine{},
+}
+
+type rules struct{}
+
+// New starts a game. variant is a start position in FEN; "" or nil is the
+// standard one.
+func (rules) New(variant any) (game.State, error) {
+	fen, _ := variant.(string)
+	if fen == "" {
+		return NewGame(), nil
+	}
+	state, err := ParseFEN(fen)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FEN: %v", err)
+	}
+	if state.Status != "playing" {
+		return nil, fmt.Errorf("invalid FEN: game is already over")
+	}
+	return state, nil
+}
+
+func (rules) Decode(data []byte) (game.State, error) {
+	var s GameState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if s.Turn != "White" && s.Turn != "Black" {
+		return nil, fmt.Errorf("stored game has no side to move")
+	}
+	return s, nil
+}
+
+// FormatMove writes a move in UCI notation
+func FormatMove(from, to Pos, promotion string) string {
+	return SquareName(from) + SquareName(to) + strings.ToLower(promotion)
+}
+
+// ParseMove reads a move in UCI notation. The promotion piece comes back
+// upper case, or "" if there is none.
+func ParseMove(s string) (from, to Pos, promotion string, err error) {
+	if len(s) != 4 && len(s) != 5 {
+		return from, to, "", fmt.Errorf("move %q is not like e2e4", s)
+	}
+	if from, err = ParseSquare(s[:2]); err != nil {
+		return from, to, "", err
+	}
+	if to, err = ParseSquare(s[2:4]); err != nil {
+		return from, to, "", err
+	}
+	return from, to, strings.ToUpper(s[4:]), nil
+}
+
+// colorOf is the colour seat plays
+func colorOf(seat string) string {
+	if seat == game.O {
+		return "Black"
+	}
+	return "White"
+}
+
+// seatOf is the seat playing color, or "" for a draw
+func seatOf(color string) string {
+	switch color {
+	case "White":
+		return game.X
+	case "Black":
+		return game.O
+	}
+	return ""
+}
+
+func (s GameState) ToMove() string {
+	return seatOf(s.Turn)
+}
+
+func (s GameState) Check(seat, move string) error {
+	if s.Status != "playing" {
+		return fmt.Errorf("game is over")
+	}
+	color := colorOf(seat)
+	if s.Turn != color {
+		return game.ErrNotYourTurn
+	}
+	from, to, promotion, err := ParseMove(move)
+	if err != nil {
+		return err
+	}
+	piece := s.Board[from.Row][from.Col]
+	if piece.IsEmpty() || piece.IsWhite != (color == "White") {
+		return fmt.Errorf("no %s piece on %s", color, SquareName(from))
+	}
+	if !GetLegalMoves(s, from.Row, from.Col)[to] {
+		return fmt.Errorf("%s cannot move there", piece.Type)
+	}
+
+	promotes := piece.Type == "P" && (to.Row == 0 || to.Row == 7)
+	switch {
+	case promotes && promotion != "" && !strings.Contains("QRBN", promotion):
+		return fmt.Errorf("cannot promote to %q", promotion)
+	case !promotes && promotion != "":
+		return fmt.Errorf("only a pawn on the last rank promotes")
+	}
+	return nil
+}
+
+func (s GameState) Apply(seat, move string) game.State {
+	from, to, promotion, _ := ParseMove(move)
+	return ApplyMove(s, from, to, promotion)
+}
+
+func (s GameState) Outcome() game.Outcome {
+	return game.Outcome{Over: s.Status != "playing", Winner: seatOf(s.Winner), Reason: s.Reason}
+}
+
+// Rematch replays a custom position as set up, including who moves first.
+// Otherwise first plays first from the standard position.
+func (s GameState) Rematch(first string) game.State {
+	if s.StartFEN != "" {
+		if state, err := ParseFEN(s.StartFEN); err == nil {
+			return state
+		}
+	}
+	state := NewGame()
+	state.Turn = colorOf(first)
+	return state
+}
+
+// Timeout ends the game because loser ran out of time. The opponent wins
+// unless they have nothing left to mate with, which makes it a draw.
+func (s GameState) Timeout(loser string) game.State {
+	s.Status = "finished"
+	s.Reason = game.ReasonTimeout
+	winner := colorOf(game.Other(loser))
+	s.Winner = winner
+	if !hasMatingMaterial(s.Board, winner == "White") {
+		s.Winner = "Draw"
+	}
+	return s
+}
+
+// hasMatingMaterial reports whether isWhite has enough pieces that some
+// sequence of legal moves could mate: any pawn, rook or queen, or two minors.
+func hasMatingMaterial(board [8][8]Piece, isWhite bool) bool {
+	minors := 0
+	for r := 0; r < 8; r++ {
+		for c := 0; c < 8; c++ {
+			p := board[r][c]
+			if p.IsEmpty() || p.IsWhite != isWhite {
+				continue
+			}
+			switch p.Type {
+			case "P", "R", "Q":
+				return true
+			case "B", "N":
+				minors++
+			}
+		}
+	}
+	return minors >= 2
+}
+
+// Record exports the game as PGN with its players and start date. Solo
+// games against the engine have no room code and work too.
+func (s GameState) Record(info game.Info) string {
+	site := "TermPlay"
+	if info.Code != "" {
+		site += " room " + info.Code
+	}
+	return PGN(s, PGNHeaders{
+		Event: "TermPlay game",
+		Site:  site,
+		Date:  time.Unix(info.StartedAt, 0).UTC().Format("2006.01.02"),
+		White: info.NameX,
+		Black: info.NameO,
+	})
+}
+
+func (s GameState) MoveCount() int {
+	return len(s.Moves)
+}
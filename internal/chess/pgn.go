@@ -0,0 +1,178 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PGNHeaders are the tag values PGN needs that the game state doesn't know
+type PGNHeaders struct {
+	Event string
+	Site  string
+	Date  string // YYYY.MM.DD
+	White string
+	Black string
+}
+
+// moveSAN returns the move from->to in Standard Algebraic Notation, without
+// the check or mate suffix, which ApplyMove adds once it knows the outcome.
+// state is the position before the move.
+func moveSAN(state GameState, from, to Pos, promotion string) string {
+	piece := state.Board[from.Row][from.Col]
+	target := state.Board[to.Row][to.Col]
+
+	if piece.Type == "K" && (to.Col-from.Col == 2 || from.Col-to.Col == 2) {
+		if to.Col == 6 {
+			return "O-O"
+		}
+		return "O-O-O"
+	}
+
+	isEnPassant := piece.Type == "P" && state.EnPassantTarget != nil && *state.EnPassantTarget == to
+	capture := !target.IsEmpty() || isEnPassant
+
+	var sb strings.Builder
+	if piece.Type == "P" {
+		if capture {
+			sb.WriteByte(fenFiles[from.Col])
+		}
+	} else {
+		sb.WriteString(piece.Type)
+		sb.WriteString(disambiguate(state, piece, from, to))
+	}
+	if capture {
+		sb.WriteByte('x')
+	}
+	sb.WriteString(SquareName(to))
+
+	if piece.Type == "P" && (to.Row == 0 || to.Row == 7) {
+		if promotion == "" {
+			promotion = "Q"
+		}
+		sb.WriteString("=" + promotion)
+	}
+	return sb.String()
+}
+
+// disambiguate returns the file, rank or square needed to tell the moving
+// piece apart from others of the same kind that could reach to as well.
+func disambiguate(state GameState, piece Piece, from, to Pos) string {
+	sameFile, sameRank, others := false, false, false
+	for r := 0; r < 8; r++ {
+		for c := 0; c < 8; c++ {
+			p := state.Board[r][c]
+			if (r == from.Row && c == from.Col) || p.Type != piece.Type || p.IsWhite != piece.IsWhite {
+				continue
+			}
+			if !GetLegalMoves(state, r, c)[to] {
+				continue
+			}
+			others = true
+			if c == from.Col {
+				sameFile = true
+			}
+			if r == from.Row {
+				sameRank = true
+			}
+		}
+	}
+
+	switch {
+	case !others:
+		return ""
+	case !sameFile:
+		return fenFiles[from.Col : from.Col+1]
+	case !sameRank:
+		return fmt.Sprintf("%d", 8-from.Row)
+	default:
+		return SquareName(from)
+	}
+}
+
+// Result returns the PGN result token for the game: "1-0", "0-1",
+// "1/2-1/2", or "*" while it is still going.
+func Result(state GameState) string {
+	if state.Status == "playing" {
+		return "*"
+	}
+	switch state.Winner {
+	case "White":
+		return "1-0"
+	case "Black":
+		return "0-1"
+	case "Draw":
+		return "1/2-1/2"
+	}
+	return "*"
+}
+
+// PGN exports the game, finished or not, in Portable Game Notation
+func PGN(state GameState, h PGNHeaders) string {
+	result := Result(state)
+
+	var sb strings.Builder
+	tag := func(name, value string) {
+		if value == "" {
+			value = "?"
+		}
+		value = strings.ReplaceAll(value, `\`, `\\`)
+		value = strings.ReplaceAll(value, `"`, `\"`)
+		fmt.Fprintf(&sb, "[%s \"%s\"]\n", name, value)
+	}
+
+	// Seven Tag Roster first, in its required order
+	tag("Event", h.Event)
+	tag("Site", h.Site)
+	tag("Date", h.Date)
+	tag("Round", "-")
+	tag("White", h.White)
+	tag("Black", h.Black)
+	tag("Result", result)
+
+	// Games from a custom position have to say where they began
+	moveNo, whiteToMove := 1, true
+	if state.StartFEN != "" {
+		tag("SetUp", "1")
+		tag("FEN", state.StartFEN)
+		if start, err := ParseFEN(state.StartFEN); err == nil {
+			moveNo, whiteToMove = start.FullMoveNumber, start.Turn == "White"
+		}
+	}
+	if state.Reason != "" {
+		tag("Termination", state.Reason)
+	}
+	sb.WriteByte('\n')
+
+	// Movetext, wrapped at 80 columns
+	var tokens []string
+	for i, san := range state.Moves {
+		if whiteToMove {
+			tokens = append(tokens, fmt.Sprintf("%d.", moveNo))
+		} else if i == 0 {
+			tokens = append(tokens, fmt.Sprintf("%d...", moveNo))
+		}
+		tokens = append(tokens, san)
+		if !whiteToMove {
+			moveNo++
+		}
+		whiteToMove = !whiteToMove
+	}
+	tokens = append(tokens, result)
+
+	line := 0
+	for i, t := range tokens {
+		if i > 0 {
+			if line+1+len(t) > 80 {
+				sb.WriteByte('\n')
+				line = 0
+			} else {
+				sb.WriteByte(' ')
+				line++
+			}
+		}
+		sb.WriteString(t)
+		line += len(t)
+	}
+	sb.WriteByte('\n')
+	return sb.String()
+}
@@ -0,0 +1,139 @@
+package chess
+
+import (
+	"strings"
+	"testing"
+)
+
+// play makes UCI moves from the position in fen, failing on any that isn't
+// legal
+func play(t *testing.T, fen string, moves ...string) GameState {
+	t.Helper()
+	s, err := ParseFEN(fen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mv := range moves {
+		from, to, promo, err := ParseMove(mv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !GetLegalMoves(s, from.Row, from.Col)[to] {
+			t.Fatalf("%s is not legal in %s", mv, ToFEN(s))
+		}
+		s = ApplyMove(s, from, to, promo)
+	}
+	return s
+}
+
+func TestSAN(t *testing.T) {
+	tests := []struct {
+		name, fen, move, want string
+	}{
+		{"pawn push", StartFEN, "e2e4", "e4"},
+		{"knight", StartFEN, "g1f3", "Nf3"},
+		{"knights on one rank", "4k3/8/8/8/8/8/8/1N2KN2 w - - 0 1", "b1d2", "Nbd2"},
+		{"rooks on one file", "4k3/R7/8/8/8/8/8/R3K3 w - - 0 1", "a1a4", "R1a4"},
+		{"three queens", "6k1/8/8/8/Q6Q/8/8/Q3K3 w - - 0 1", "a4d4", "Qa4d4"},
+		{"pinned twin needs no name", "4r2k/8/8/8/4N3/8/8/1N2K3 w - - 0 1", "b1d2", "Nd2"},
+		{"capture", "4k3/8/8/3p4/4P3/8/8/4K3 w - - 0 1", "e4d5", "exd5"},
+		{"en passant", "4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1", "e5d6", "exd6"},
+		{"kingside castle", "4k3/8/8/8/8/8/8/4K2R w K - 0 1", "e1g1", "O-O"},
+		{"queenside castle", "r3k3/8/8/8/8/8/8/4K3 b q - 0 1", "e8c8", "O-O-O"},
+		{"promotion", "8/4P3/8/8/8/8/8/k3K3 w - - 0 1", "e7e8", "e8=Q"},
+		{"underpromotion", "8/4P3/8/8/8/8/8/3k1K2 w - - 0 1", "e7e8n", "e8=N"},
+		{"check", "4k3/8/8/8/8/8/8/R3K3 w - - 0 1", "a1a8", "Ra8+"},
+		{"mate", "6k1/5ppp/8/8/8/8/8/R3K3 w - - 0 1", "a1a8", "Ra8#"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := play(t, tt.fen, tt.move)
+			if got := s.Moves[len(s.Moves)-1]; got != tt.want {
+				t.Errorf("SAN = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPGN(t *testing.T) {
+	tests := []struct {
+		name  string
+		fen   string
+		moves []string
+		want  []string // Lines that must be in the PGN
+	}{
+		{
+			"scholar's mate", StartFEN,
+			[]string{"e2e4", "e7e5", "f1c4", "b8c6", "d1h5", "g8f6", "h5f7"},
+			[]string{
+				`[Result "1-0"]`,
+				`[Termination "checkmate"]`,
+				"1. e4 e5 2. Bc4 Nc6 3. Qh5 Nf6 4. Qxf7# 1-0",
+			},
+		},
+		{
+			"unfinished", StartFEN,
+			[]string{"d2d4"},
+			[]string{`[Result "*"]`, "1. d4 *"},
+		},
+		{
+			"black to move from a position", "4k3/8/8/8/8/8/8/R3K3 b - - 0 12",
+			[]string{"e8d7", "a1a7"},
+			[]string{`[SetUp "1"]`, `[FEN "4k3/8/8/8/8/8/8/R3K3 b - - 0 12"]`, "12... Kd7 13. Ra7+ *"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pgn := PGN(play(t, tt.fen, tt.moves...), PGNHeaders{White: "Ann", Black: "Bo"})
+			lines := strings.Split(pgn, "\n")
+			for _, want := range tt.want {
+				found := false
+				for _, line := range lines {
+					found = found || line == want
+				}
+				if !found {
+					t.Errorf("no line %q in\n%s", want, pgn)
+				}
+			}
+		})
+	}
+}
+
+func TestPGNTags(t *testing.T) {
+	pgn := PGN(NewGame(), PGNHeaders{White: `Al "The Rook"`})
+	for _, want := range []string{
+		`[Event "?"]`,
+		`[Round "-"]`,
+		`[White "Al \"The Rook\""]`,
+		`[Black "?"]`,
+	} {
+		if !strings.Contains(pgn, want+"\n") {
+			t.Errorf("no tag %s in\n%s", want, pgn)
+		}
+	}
+	if strings.Contains(pgn, "[FEN ") {
+		t.Errorf("a game from the start has a FEN tag:\n%s", pgn)
+	}
+}
+
+func TestPGNWrapsAt80(t *testing.T) {
+	// Knights out and back, long enough to take several lines
+	var moves []string
+	for i := 0; i < 20; i++ {
+		moves = append(moves, "g1f3", "g8f6", "f3g1", "f6g8")
+	}
+	s, err := ParseFEN(StartFEN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mv := range moves {
+		from, to, _, _ := ParseMove(mv)
+		s = ApplyMove(s, from, to, "")
+		s.History = nil // Keep threefold repetition from ending it
+	}
+	for _, line := range strings.Split(PGN(s, PGNHeaders{}), "\n") {
+		if len(line) > 80 {
+			t.Errorf("line is %d long: %q", len(line), line)
+		}
+	}
+}
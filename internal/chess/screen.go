@@ -0,0 +1,406 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// promotionPieces are the choices offered when a pawn reaches the last rank
+var promotionPieces = []string{"Q", "R", "B", "N"}
+
+// screen is the board cursor, the selected piece and its legal moves, and
+// a promotion waiting for the player to pick a piece
+type screen struct {
+	synced bool
+	r, c   int
+
+	selected   bool
+	selR, selC int
+	validMoves map[Pos]bool
+
+	promoting          bool
+	promoFrom, promoTo Pos
+	promoIndex         int // into promotionPieces
+
+	useNerdFont bool
+}
+
+func newScreen() game.Screen {
+	return screen{validMoves: make(map[Pos]bool), useNerdFont: true}
+}
+
+// Sync puts the cursor on the player's king file, on their own side of the
+// board, when the first snapshot arrives
+func (sc screen) Sync(v game.View) game.Screen {
+	if !sc.synced {
+		sc.synced = true
+		sc.r, sc.c = 7, 4
+		if v.Seat == game.O {
+			sc.r = 0
+		}
+	}
+	return sc
+}
+
+func (sc screen) deselect() screen {
+	sc.selected = false
+	sc.validMoves = make(map[Pos]bool)
+	return sc
+}
+
+func (sc screen) Update(v game.View, msg tea.KeyMsg) (game.Screen, game.Action) {
+	s := v.State.(GameState)
+	if sc.promoting {
+		return sc.updatePromotion(msg)
+	}
+
+	// Black sees the board from their side, so the arrows flip too
+	step := 1
+	if v.Seat == game.O {
+		step = -1
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		sc.r = clampSquare(sc.r - step)
+	case "down", "j":
+		sc.r = clampSquare(sc.r + step)
+	case "left", "h":
+		sc.c = clampSquare(sc.c - step)
+	case "right", "l":
+		sc.c = clampSquare(sc.c + step)
+	case "f":
+		sc.useNerdFont = !sc.useNerdFont
+	case "c":
+		// Show the FEN and copy it, for players and spectators alike
+		return sc, game.Action{Share: &game.Share{Title: "POSITION (FEN)", Text: ToFEN(s)}}
+	case "p":
+		// Same for the whole game so far as PGN
+		return sc, game.Action{Share: &game.Share{Title: "GAME (PGN)", Text: s.Record(v.Info)}}
+	case "esc":
+		if !sc.selected {
+			return sc, game.Action{Unhandled: true}
+		}
+		return sc.deselect(), game.Action{}
+	case "enter", " ":
+		if !v.MyTurn() {
+			return sc, game.Action{}
+		}
+		return sc.choose(s, v.Seat == game.X)
+	default:
+		return sc, game.Action{Unhandled: true}
+	}
+	return sc, game.Action{}
+}
+
+func clampSquare(i int) int {
+	return min(max(i, 0), 7)
+}
+
+// choose selects the piece under the cursor, or moves the selected piece
+// there
+func (sc screen) choose(s GameState, white bool) (game.Screen, game.Action) {
+	if sc.selected {
+		// Choosing the selected piece again puts it down
+		if sc.r == sc.selR && sc.c == sc.selC {
+			return sc.deselect(), game.Action{}
+		}
+
+		if sc.validMoves[Pos{Row: sc.r, Col: sc.c}] {
+			from := Pos{Row: sc.selR, Col: sc.selC}
+			to := Pos{Row: sc.r, Col: sc.c}
+			sc = sc.deselect()
+
+			// A pawn reaching the last rank waits for the player to pick a piece
+			if s.Board[from.Row][from.Col].Type == "P" && (to.Row == 0 || to.Row == 7) {
+				sc.promoting = true
+				sc.promoFrom, sc.promoTo = from, to
+				sc.promoIndex = 0
+				return sc, game.Action{}
+			}
+			// The server validates and applies the move
+			return sc, game.Action{Move: FormatMove(from, to, "")}
+		}
+	}
+
+	// Pick up a piece of our own, or drop the selection
+	p := s.Board[sc.r][sc.c]
+	if p.IsEmpty() || p.IsWhite != white {
+		return sc.deselect(), game.Action{}
+	}
+	sc.selected = true
+	sc.selR, sc.selC = sc.r, sc.c
+	sc.validMoves = GetLegalMoves(s, sc.r, sc.c)
+	return sc, game.Action{}
+}
+
+func (sc screen) updatePromotion(msg tea.KeyMsg) (game.Screen, game.Action) {
+	key := msg.String()
+	switch key {
+	case "left", "h":
+		sc.promoIndex = (sc.promoIndex + len(promotionPieces) - 1) % len(promotionPieces)
+	case "right", "l":
+		sc.promoIndex = (sc.promoIndex + 1) % len(promotionPieces)
+	case "q", "r", "b", "n", "enter", " ":
+		piece := promotionPieces[sc.promoIndex]
+		if len(key) == 1 && key != " " {
+			piece = strings.ToUpper(key)
+		}
+		sc.promoting = false
+		return sc, game.Action{Move: FormatMove(sc.promoFrom, sc.promoTo, piece)}
+	case "esc":
+		// Take the move back; nothing was sent yet
+		sc.promoting = false
+	}
+	return sc, game.Action{}
+}
+
+// Modal is the promotion picker
+func (sc screen) Modal(v game.View) string {
+	if !sc.promoting {
+		return ""
+	}
+	var choices []string
+	for i, t := range promotionPieces {
+		piece := Piece{Type: t, IsWhite: v.Seat == game.X}
+		label := fmt.Sprintf(" %s %s ", pieceSymbol(piece, sc.useNerdFont), pieceName(t))
+		if i == sc.promoIndex {
+			choices = append(choices, styles.ItemFocused.Render(label))
+		} else {
+			choices = append(choices, styles.ItemBlurred.Render(label))
+		}
+	}
+	return lipgloss.JoinVertical(lipgloss.Center,
+		styles.Title.Render("PROMOTE PAWN"),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Center, choices...),
+		"\n",
+		styles.Subtle.Render("[←/→] Choose • [Enter] Promote • [Q/R/B/N] Quick pick • [Esc] Cancel"),
+	)
+}
+
+func (sc screen) Help(v game.View) string {
+	return "arrows/hjkl move • enter/space select • esc deselect • f font • c FEN • p PGN • q quit"
+}
+
+func (sc screen) Render(v game.View) string {
+	s := v.State.(GameState)
+	sqW, sqH := game.SquareSize(8, v.Width, v.Height)
+
+	isFlipped := v.Seat == game.O
+
+	files := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	ranks := []string{"8", "7", "6", "5", "4", "3", "2", "1"}
+	if isFlipped {
+		files = []string{"h", "g", "f", "e", "d", "c", "b", "a"}
+		ranks = []string{"1", "2", "3", "4", "5", "6", "7", "8"}
+	}
+
+	grid := game.LabeledGrid(files, ranks, sqW, sqH, func(r, c int) string {
+		isLight := (r+c)%2 == 0
+		bg := styles.ChessDarkSquare
+		if isLight {
+			bg = styles.ChessLightSquare
+		}
+
+		br, bc := r, c
+		if isFlipped {
+			br = 7 - r
+			bc = 7 - c
+		}
+
+		piece := s.Board[br][bc]
+		fg := styles.ChessBlackPiece
+		if piece.IsWhite {
+			fg = styles.ChessWhitePiece
+		}
+
+		isCursor := (sc.r == br && sc.c == bc)
+		isSelected := (sc.selected && sc.selR == br && sc.selC == bc)
+		isValidMove := sc.validMoves[Pos{Row: br, Col: bc}]
+		isCapture := isValidMove && !s.Board[br][bc].IsEmpty()
+
+		if isSelected {
+			bg = styles.ChessSelected
+		} else if isCapture {
+			bg = styles.ChessCapture
+		} else if isValidMove {
+			bg = styles.ChessHighlight
+		}
+
+		if isCursor && !isSelected {
+			bg = game.CursorSquare
+		}
+		return game.Square(pieceSymbol(piece, sc.useNerdFont), fg, bg, sqW, sqH)
+	})
+
+	return game.BoardFrame(lipgloss.JoinVertical(lipgloss.Center,
+		styles.Title.Render("CHESS"),
+		game.Scoreboard(v),
+		"",
+		grid,
+		"",
+		renderStatus(v, s),
+	))
+}
+
+// renderStatus says how the game ended, or whose move it is and whether
+// they are in check, followed by the last move
+func renderStatus(v game.View, s GameState) string {
+	var statusText string
+	var statusColor lipgloss.Color = lipgloss.Color("#CCCCCC")
+	isBold := false
+
+	info := v.Info
+	if info.Status == "waiting" {
+		statusText = "Opponent disconnected. Waiting..."
+	} else if info.Status == "finished" {
+		isBold = true
+		statusColor = styles.ChessCapture
+		winner := strings.ToUpper(colorOf(info.Winner))
+		if info.Winner == "" {
+			reason := info.Reason
+			if reason == "" {
+				reason = ReasonStalemate
+			}
+			statusText = strings.ToUpper(reason) + " - DRAW!"
+		} else if info.Reason == game.ReasonTimeout {
+			statusText = "TIME OUT! " + winner + " WINS!"
+		} else if info.Reason == ReasonCheckmate {
+			statusText = "CHECKMATE! " + winner + " WINS!"
+		} else {
+			statusText = winner + " WINS!"
+		}
+	} else {
+		if IsInCheck(s.Board, s.Turn == "White") {
+			isBold = true
+			statusColor = styles.ChessCapture
+			statusText = "CHECK! "
+		}
+
+		if v.Seat == "" {
+			statusText += "[SPECTATING]"
+		} else if v.MyTurn() {
+			statusText += "Your turn"
+		} else if opponent := info.Name(game.Other(v.Seat)); info.Thinking {
+			statusText += opponent + " is thinking..."
+		} else {
+			statusText += opponent + "'s turn"
+		}
+	}
+
+	status := lipgloss.NewStyle().
+		Foreground(statusColor).
+		Bold(isBold).
+		Render(statusText)
+	if len(s.Moves) > 0 {
+		status = lipgloss.JoinVertical(lipgloss.Center, status, styles.Subtle.Render("Last move: "+s.Moves[len(s.Moves)-1]))
+	}
+	return status
+}
+
+// Nerd Font chess icons (md-chess_* from Material Design Icons)
+const (
+	nfKing   = "\U000F0857" // nf-md-chess_king
+	nfQueen  = "\U000F085A" // nf-md-chess_queen
+	nfRook   = "\U000F085B" // nf-md-chess_rook
+	nfBishop = "\U000F085C" // nf-md-chess_bishop
+	nfKnight = "\U000F0858" // nf-md-chess_knight
+	nfPawn   = "\U000F0859" // nf-md-chess_pawn
+)
+
+// Unicode fallback chess symbols (distinct sets)
+const (
+	// White
+	ucWhiteKing   = "♔"
+	ucWhiteQueen  = "♕"
+	ucWhiteRook   = "♖"
+	ucWhiteBishop = "♗"
+	ucWhiteKnight = "♘"
+	ucWhitePawn   = "♙"
+
+	// Black
+	ucBlackKing   = "♚"
+	ucBlackQueen  = "♛"
+	ucBlackRook   = "♜"
+	ucBlackBishop = "♝"
+	ucBlackKnight = "♞"
+	ucBlackPawn   = "♟"
+)
+
+func pieceName(t string) string {
+	switch t {
+	case "Q":
+		return "Queen"
+	case "R":
+		return "Rook"
+	case "B":
+		return "Bishop"
+	case "N":
+		return "Knight"
+	}
+	return t
+}
+
+func pieceSymbol(p Piece, useNerd bool) string {
+	if p.IsEmpty() {
+		return ""
+	}
+
+	if useNerd {
+		switch p.Type {
+		case "K":
+			return nfKing
+		case "Q":
+			return nfQueen
+		case "R":
+			return nfRook
+		case "B":
+			return nfBishop
+		case "N":
+			return nfKnight
+		case "P":
+			return nfPawn
+		}
+	}
+
+	// Unicode fallback
+	if p.IsWhite {
+		switch p.Type {
+		case "K":
+			return ucWhiteKing
+		case "Q":
+			return ucWhiteQueen
+		case "R":
+			return ucWhiteRook
+		case "B":
+			return ucWhiteBishop
+		case "N":
+			return ucWhiteKnight
+		case "P":
+			return ucWhitePawn
+		}
+	} else {
+		switch p.Type {
+		case "K":
+			return ucBlackKing
+		case "Q":
+			return ucBlackQueen
+		case "R":
+			return ucBlackRook
+		case "B":
+			return ucBlackBishop
+		case "N":
+			return ucBlackKnight
+		case "P":
+			return ucBlackPawn
+		}
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+package chess
+
+import (
+	"strings"
+
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// setup picks a time control and, optionally, a start position
+type setup struct {
+	field       int             // 0: time control, 1: FEN
+	clockPreset int             // into game.TimeControlPresets; one past the end = custom
+	clockInput  textinput.Model // Custom time control, e.g. "15+10"
+	fenInput    textinput.Model
+}
+
+func newSetup() game.Setup {
+	ci := textinput.New()
+	ci.Placeholder = "min+sec, e.g. 15+10"
+	ci.Prompt = "> "
+	ci.CharLimit = 10
+	ci.Width = 24
+
+	fi := textinput.New()
+	fi.Placeholder = "Standard (paste a FEN to customize)"
+	fi.Prompt = "> "
+	fi.CharLimit = 100
+	fi.Width = 60
+
+	return setup{clockInput: ci, fenInput: fi}
+}
+
+func (st setup) custom() bool {
+	return st.clockPreset == len(game.TimeControlPresets)
+}
+
+func (st setup) Update(msg tea.KeyMsg) (game.Setup, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg.String() {
+	case "tab", "shift+tab":
+		st.field = 1 - st.field
+		return st, st.focus()
+	}
+
+	if st.field == 1 {
+		st.fenInput, cmd = st.fenInput.Update(msg)
+		return st, cmd
+	}
+	switch msg.String() {
+	case "left", "right":
+		// The presets plus one "Custom" slot
+		n := len(game.TimeControlPresets) + 1
+		if msg.String() == "left" {
+			st.clockPreset = (st.clockPreset + n - 1) % n
+		} else {
+			st.clockPreset = (st.clockPreset + 1) % n
+		}
+		return st, st.focus()
+	}
+	if st.custom() {
+		st.clockInput, cmd = st.clockInput.Update(msg)
+	}
+	return st, cmd
+}
+
+// focus moves the text cursor to whichever input is active, if any
+func (st *setup) focus() tea.Cmd {
+	st.fenInput.Blur()
+	st.clockInput.Blur()
+	switch {
+	case st.field == 0 && st.custom():
+		st.clockInput.Focus()
+		return textinput.Blink
+	case st.field == 1:
+		st.fenInput.Focus()
+		return textinput.Blink
+	}
+	return nil
+}
+
+func (st setup) View() string {
+	dimmed := lipgloss.Color("#3d4d5c")
+
+	var presets []string
+	for i, p := range game.TimeControlPresets {
+		presets = append(presets, game.RenderPreset(p.Name, i == st.clockPreset))
+	}
+	presets = append(presets, game.RenderPreset("Custom", st.custom()))
+	clockView := lipgloss.JoinHorizontal(lipgloss.Center, presets...)
+	if st.custom() {
+		clockView = lipgloss.JoinVertical(lipgloss.Center, clockView, "", st.clockInput.View())
+	}
+	clockBox := styles.ListContainer.Width(66).Align(lipgloss.Center)
+	if st.field != 0 {
+		clockBox = clockBox.BorderForeground(dimmed)
+	}
+
+	fenBox := styles.ListContainer.Width(66)
+	if st.field != 1 {
+		fenBox = fenBox.BorderForeground(dimmed)
+	}
+	return lipgloss.JoinVertical(lipgloss.Center,
+		"Time Control:",
+		clockBox.Render(clockView),
+		"",
+		"Start Position:",
+		fenBox.Render(st.fenInput.View()),
+	)
+}
+
+func (st setup) Help() string {
+	return "←/→: Time Control • Tab: Switch Field"
+}
+
+func (st setup) Settings() (game.Settings, error) {
+	settings := game.Settings{Variant: strings.TrimSpace(st.fenInput.Value())}
+	if !st.custom() {
+		settings.TimeControl = game.TimeControlPresets[st.clockPreset].TC
+		return settings, nil
+	}
+	tc, err := game.ParseTimeControl(st.clockInput.Value())
+	if err != nil {
+		return game.Settings{}, err
+	}
+	settings.TimeControl = tc
+	return settings, nil
+}
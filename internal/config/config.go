@@ -9,6 +9,8 @@ import (
 )
 
 var (
+	Storage      = "firebase" // "firebase", "bolt" or "memory"
+	BoltPath     = "termplay.db"
 	DBURL        = ""
 	CredPath     = ""
 	SyncInterval = 500 * time.Millisecond
@@ -20,6 +22,12 @@ func init() {
 	// Load .env file if present
 	_ = godotenv.Load()
 
+	if v := os.Getenv("STORAGE"); v != "" {
+		Storage = v
+	}
+	if v := os.Getenv("BOLT_PATH"); v != "" {
+		BoltPath = v
+	}
 	if v := os.Getenv("FIREBASE_DB_URL"); v != "" {
 		DBURL = v
 	}
@@ -30,6 +38,12 @@ func init() {
 		// Or assume user sets GOOGLE_APPLICATION_CREDENTIALS path
 	}
 
+	if v := os.Getenv("SYNC_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			SyncInterval = d
+		}
+	}
+
 	if v := os.Getenv("HOST"); v != "" {
 		Host = v
 	}
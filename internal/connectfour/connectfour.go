@@ -0,0 +1,75 @@
+// Package connectfour holds the rules for Connect Four: discs are dropped
+// into the columns of an upright 7×6 grid, fall to the lowest free cell,
+// and the first to line up four wins.
+package connectfour
+
+import "github.com/aminshahid573/termplay/internal/tictactoe"
+
+// The board is Rows high and Cols wide; cell r,c is at index r*Cols+c with
+// row 0 at the top
+const (
+	Rows = 6
+	Cols = 7
+)
+
+// size is the board as an m,n,k game, for finding four in a row
+var size = tictactoe.Size{Rows: Rows, Cols: Cols, K: 4}
+
+// State is a whole game. Once someone has four in a row, Winner and Line
+// say who and where.
+type State struct {
+	Board    []string `json:"board"`
+	Turn     string   `json:"turn"` // "X" or "O"
+	Over     bool     `json:"over,omitempty"`
+	Winner   string   `json:"winner,omitempty"`
+	Line     []int    `json:"line,omitempty"`
+	LastMove int      `json:"lastMove"` // Cell index, -1 before the first drop
+}
+
+// New returns an empty board with first to drop
+func New(first string) State {
+	return State{Board: size.NewBoard(), Turn: first, LastMove: -1}
+}
+
+// Valid reports whether s has a whole board, as a stored game should
+func (s State) Valid() bool {
+	return len(s.Board) == Rows*Cols
+}
+
+// Landing is the row a disc dropped in col comes to rest on, or -1 if the
+// column is full or off the board
+func (s State) Landing(col int) int {
+	if col < 0 || col >= Cols {
+		return -1
+	}
+	for r := Rows - 1; r >= 0; r-- {
+		if v := s.Board[r*Cols+col]; v == " " || v == "" {
+			return r
+		}
+	}
+	return -1
+}
+
+// Drop puts the side to move's disc in col, which must have room, and
+// returns the new state. s itself is left alone.
+func Drop(s State, col int) State {
+	idx := s.Landing(col)*Cols + col
+	s.Board = append([]string(nil), s.Board...)
+	s.Board[idx] = s.Turn
+	s.LastMove = idx
+
+	if w, line := size.CheckWinner(s.Board); w != "" {
+		s.Over, s.Winner, s.Line = true, w, line
+		return s
+	}
+	if tictactoe.CheckDraw(s.Board) {
+		s.Over = true
+		return s
+	}
+	if s.Turn == "X" {
+		s.Turn = "O"
+	} else {
+		s.Turn = "X"
+	}
+	return s
+}
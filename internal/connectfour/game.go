@@ -0,0 +1,70 @@
+package connectfour
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Game is Connect Four. The host (X) plays Red and the guest (O) Yellow.
+// Moves are column indexes, 0 to 6.
+var Game = &game.Game{
+	ID:        "connectfour",
+	Name:      "Connect Four",
+	Seats:     [2]string{"Red", "Yellow"},
+	Rules:     rules{},
+	NewScreen: newScreen,
+}
+
+type rules struct{}
+
+func (rules) New(variant any) (game.State, error) {
+	return New(game.X), nil
+}
+
+func (rules) Decode(data []byte) (game.State, error) {
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if !s.Valid() {
+		return nil, fmt.Errorf("stored board has %d cells", len(s.Board))
+	}
+	return s, nil
+}
+
+func (s State) ToMove() string {
+	return s.Turn
+}
+
+func (s State) Check(seat, move string) error {
+	if s.Over {
+		return fmt.Errorf("game is over")
+	}
+	if seat != s.Turn {
+		return game.ErrNotYourTurn
+	}
+	col, err := strconv.Atoi(move)
+	if err != nil || col < 0 || col >= Cols {
+		return fmt.Errorf("column %s is off the board", move)
+	}
+	if s.Landing(col) < 0 {
+		return fmt.Errorf("column %d is full", col+1)
+	}
+	return nil
+}
+
+func (s State) Apply(seat, move string) game.State {
+	col, _ := strconv.Atoi(move)
+	return Drop(s, col)
+}
+
+func (s State) Outcome() game.Outcome {
+	return game.Outcome{Over: s.Over, Winner: s.Winner}
+}
+
+func (s State) Rematch(first string) game.State {
+	return New(first)
+}
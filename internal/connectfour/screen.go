@@ -0,0 +1,132 @@
+package connectfour
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// screen is a column cursor above the board
+type screen struct {
+	col int
+}
+
+func newScreen() game.Screen {
+	return screen{col: Cols / 2}
+}
+
+func (sc screen) Sync(v game.View) game.Screen {
+	return sc
+}
+
+func (sc screen) Update(v game.View, msg tea.KeyMsg) (game.Screen, game.Action) {
+	s := v.State.(State)
+	switch msg.String() {
+	case "left", "h":
+		if sc.col > 0 {
+			sc.col--
+		}
+	case "right", "l":
+		if sc.col < Cols-1 {
+			sc.col++
+		}
+	case " ", "enter":
+		if v.MyTurn() && s.Landing(sc.col) >= 0 {
+			return sc, game.Action{Move: strconv.Itoa(sc.col)}
+		}
+	default:
+		return sc, game.Action{Unhandled: true}
+	}
+	return sc, game.Action{}
+}
+
+func (sc screen) Render(v game.View) string {
+	s := v.State.(State)
+	cellW, cellH := computeCellSize(v.Width, v.Height)
+
+	// The disc about to drop hangs over the chosen column
+	var pointer []string
+	for c := 0; c < Cols; c++ {
+		mark := ""
+		if v.MyTurn() && c == sc.col {
+			mark = disc(s.Turn, "▼")
+		}
+		pointer = append(pointer, lipgloss.PlaceHorizontal(cellW, lipgloss.Center, mark))
+	}
+
+	var rows []string
+	for r := 0; r < Rows; r++ {
+		var cells []string
+		for c := 0; c < Cols; c++ {
+			idx := r*Cols + c
+			style := styles.DropCell
+			for _, w := range s.Line {
+				if idx == w {
+					style = styles.DropCellWin
+				}
+			}
+			content := styles.Muted.Render("·")
+			if mark := s.Board[idx]; mark == game.X || mark == game.O {
+				content = disc(mark, "●")
+			}
+			if idx == s.LastMove {
+				content = "[" + content + "]"
+			}
+			cells = append(cells, style.Width(cellW).Height(cellH).Render(content))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+	board := styles.DropBoard.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+
+	var labels []string
+	for c := 0; c < Cols; c++ {
+		labels = append(labels, lipgloss.PlaceHorizontal(cellW, lipgloss.Center, strconv.Itoa(c+1)))
+	}
+
+	// One column of padding for the board's border
+	pad := lipgloss.NewStyle().PaddingLeft(1)
+	return lipgloss.JoinVertical(lipgloss.Center,
+		styles.Title.Render("CONNECT FOUR"),
+		game.Scoreboard(v),
+		"\n",
+		lipgloss.JoinVertical(lipgloss.Left,
+			pad.Render(strings.Join(pointer, "")),
+			board,
+			pad.Render(styles.Subtle.Render(strings.Join(labels, ""))),
+		),
+		"\n",
+		game.StatusLine(v, v.Game.SeatName(s.Turn)),
+	)
+}
+
+func (sc screen) Help(v game.View) string {
+	return "←/→: Column • Space: Drop • R: Restart • Q: Quit"
+}
+
+// disc draws text in the colour of seat's discs
+func disc(seat, text string) string {
+	if seat == game.O {
+		return styles.YellowDisc.Render(text)
+	}
+	return styles.RedDisc.Render(text)
+}
+
+// computeCellSize picks the largest cell that lets the board fit the
+// terminal, down to a single line per row
+func computeCellSize(termWidth, termHeight int) (cellW, cellH int) {
+	availW := termWidth - 8
+	availH := termHeight - 14
+
+	// Two extra lines for the pointer and labels, two for the border
+	for _, sz := range [][2]int{{6, 3}, {4, 2}} {
+		if Cols*sz[0]+2 <= availW && Rows*sz[1]+4 <= availH {
+			return sz[0], sz[1]
+		}
+	}
+	return 3, 1
+}
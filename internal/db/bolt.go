@@ -0,0 +1,195 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/game"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	roomsBucket   = []byte("rooms")
+	archiveBucket = []byte("archive")
+	scoresBucket  = []byte("scores")  // A bucket per leaderboard, keyed by player
+	libraryBucket = []byte("library") // A bucket per player, keyed by "kind/name"
+)
+
+// boltKV keeps rooms in a single bbolt file, so a self-hosted server keeps
+// its rooms and win counters across restarts without needing Firebase.
+type boltKV struct {
+	db *bolt.DB
+}
+
+func newBoltStore(path string) (Store, error) {
+	bdb, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 2 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("error opening bolt db %s: %v", path, err)
+	}
+	err = bdb.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{roomsBucket, archiveBucket, scoresBucket, libraryBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		bdb.Close()
+		return nil, fmt.Errorf("error creating bolt buckets: %v", err)
+	}
+	return &localStore{kv: &boltKV{db: bdb}}, nil
+}
+
+func (kv *boltKV) view(fn func(tx roomTx) error) error {
+	return kv.db.View(func(tx *bolt.Tx) error {
+		return fn(boltTx{b: tx.Bucket(roomsBucket), records: tx.Bucket(archiveBucket), boards: tx.Bucket(scoresBucket), library: tx.Bucket(libraryBucket)})
+	})
+}
+
+func (kv *boltKV) update(fn func(tx roomTx) error) error {
+	return kv.db.Update(func(tx *bolt.Tx) error {
+		return fn(boltTx{b: tx.Bucket(roomsBucket), records: tx.Bucket(archiveBucket), boards: tx.Bucket(scoresBucket), library: tx.Bucket(libraryBucket)})
+	})
+}
+
+type boltTx struct {
+	b       *bolt.Bucket
+	records *bolt.Bucket // archived games
+	boards  *bolt.Bucket // leaderboards
+	library *bolt.Bucket // players' saved items
+}
+
+func (tx boltTx) get(code string) (Room, bool, error) {
+	v := tx.b.Get([]byte(code))
+	if v == nil {
+		return Room{}, false, nil
+	}
+	r, err := decodeRoom(v)
+	return r, err == nil, err
+}
+
+func (tx boltTx) put(r Room) error {
+	v, err := encodeRoom(r)
+	if err != nil {
+		return err
+	}
+	return tx.b.Put([]byte(r.Code), v)
+}
+
+func (tx boltTx) del(code string) error {
+	return tx.b.Delete([]byte(code))
+}
+
+func (tx boltTx) each(fn func(r Room) error) error {
+	// Collect first: fn may delete, and bolt cursors don't like that
+	var rooms []Room
+	err := tx.b.ForEach(func(k, v []byte) error {
+		r, err := decodeRoom(v)
+		if err != nil {
+			// Skip corrupt entries rather than failing the whole scan
+			return nil
+		}
+		r.Code = string(k)
+		rooms = append(rooms, r)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, r := range rooms {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tx boltTx) archive(code, record string) error {
+	return tx.records.Put([]byte(code), []byte(record))
+}
+
+func (tx boltTx) archived(code string) (string, bool, error) {
+	v := tx.records.Get([]byte(code))
+	if v == nil {
+		return "", false, nil
+	}
+	return string(v), true, nil
+}
+
+func (tx boltTx) score(board, pid string) (game.Score, bool, error) {
+	b := tx.boards.Bucket([]byte(board))
+	if b == nil {
+		return game.Score{}, false, nil
+	}
+	v := b.Get([]byte(pid))
+	if v == nil {
+		return game.Score{}, false, nil
+	}
+	var sc game.Score
+	err := json.Unmarshal(v, &sc)
+	return sc, err == nil, err
+}
+
+func (tx boltTx) putScore(board string, sc game.Score) error {
+	b, err := tx.boards.CreateBucketIfNotExists([]byte(board))
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(sc)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(sc.ID), v)
+}
+
+func (tx boltTx) scores(board string) ([]game.Score, error) {
+	b := tx.boards.Bucket([]byte(board))
+	if b == nil {
+		return nil, nil
+	}
+	var list []game.Score
+	err := b.ForEach(func(k, v []byte) error {
+		var sc game.Score
+		if err := json.Unmarshal(v, &sc); err != nil {
+			// Skip corrupt entries rather than losing the whole board
+			return nil
+		}
+		list = append(list, sc)
+		return nil
+	})
+	return list, err
+}
+
+func (tx boltTx) items(pid, kind string) (map[string]string, error) {
+	items := make(map[string]string)
+	b := tx.library.Bucket([]byte(pid))
+	if b == nil {
+		return items, nil
+	}
+	prefix := []byte(kind + "/")
+	c := b.Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		items[string(k[len(prefix):])] = string(v)
+	}
+	return items, nil
+}
+
+func (tx boltTx) putItem(pid, kind, name, data string) error {
+	b, err := tx.library.CreateBucketIfNotExists([]byte(pid))
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(kind+"/"+name), []byte(data))
+}
+
+func (tx boltTx) delItem(pid, kind, name string) error {
+	b := tx.library.Bucket([]byte(pid))
+	if b == nil {
+		return nil
+	}
+	return b.Delete([]byte(kind + "/" + name))
+}
@@ -3,10 +3,10 @@ package db
 import (
 	"context"
 	"fmt"
-	"github.com/aminshahid573/termplay/internal/chess"
 	"github.com/aminshahid573/termplay/internal/config"
-	"github.com/aminshahid573/termplay/internal/tictactoe"
+	"github.com/aminshahid573/termplay/internal/game"
 	"log"
+	"net/http"
 	"os"
 	"sort"
 
@@ -17,53 +17,15 @@ import (
 	"google.golang.org/api/option"
 )
 
-// Room is the clean, strict structure used by the Game UI
-type Room struct {
-	Code        string            `json:"code"`
-	Board       [9]string         `json:"board"`
-	Turn        string            `json:"turn"`
-	PlayerX     string            `json:"playerX"`
-	PlayerO     string            `json:"playerO"`
-	PlayerXName string            `json:"playerXName"`
-	PlayerOName string            `json:"playerOName"`
-	IsPublic    bool              `json:"isPublic"`
-	Winner      string            `json:"winner"`
-	WinningLine []int             `json:"winningLine"`
-	Status      string            `json:"status"`
-	WinsX       int               `json:"winsX"`
-	WinsO       int               `json:"winsO"`
-	Spectators  map[string]string `json:"spectators"`
-	UpdatedAt   int64             `json:"updatedAt"`
-	GameType    string            `json:"gameType"`
-	ChessState  chess.GameState   `json:"chessState"`
+// firebaseStore keeps rooms in the Firebase Realtime Database under "rooms/"
+type firebaseStore struct {
+	client *db.Client
+	stream *http.Client // for room event streams, see firebase_stream.go
 }
 
-// rawRoom is a helper struct to safely read dirty data (mixed types) from Firebase
-type rawRoom struct {
-	Code        string            `json:"code"`
-	Board       []interface{}     `json:"board"` // Loose type to prevent crashes
-	Turn        string            `json:"turn"`
-	PlayerX     string            `json:"playerX"`
-	PlayerO     string            `json:"playerO"`
-	PlayerXName string            `json:"playerXName"`
-	PlayerOName string            `json:"playerOName"`
-	IsPublic    bool              `json:"isPublic"`
-	Winner      string            `json:"winner"`
-	WinningLine []int             `json:"winningLine"`
-	Status      string            `json:"status"`
-	WinsX       int               `json:"winsX"`
-	WinsO       int               `json:"winsO"`
-	Spectators  map[string]string `json:"spectators"`
-	UpdatedAt   int64             `json:"updatedAt"`
-	GameType    string            `json:"gameType"`
-	ChessState  chess.GameState   `json:"chessState"`
-}
-
-var client *db.Client
-
-func Init() error {
+func newFirebaseStore() (*firebaseStore, error) {
 	if config.DBURL == "" {
-		return fmt.Errorf("FIREBASE_DB_URL environment variable is required")
+		return nil, fmt.Errorf("FIREBASE_DB_URL environment variable is required")
 	}
 
 	var opts []option.ClientOption
@@ -76,278 +38,134 @@ func Init() error {
 	cfg := &firebase.Config{DatabaseURL: config.DBURL}
 	app, err := firebase.NewApp(context.Background(), cfg, opts...)
 	if err != nil {
-		return fmt.Errorf("error initializing app: %v", err)
+		return nil, fmt.Errorf("error initializing app: %v", err)
 	}
-	client, err = app.Database(context.Background())
+	client, err := app.Database(context.Background())
 	if err != nil {
-		return fmt.Errorf("error initializing db client: %v", err)
-	}
-	return nil
-}
-
-// Helper to convert raw data to clean Room
-func sanitizeRoom(code string, raw rawRoom) Room {
-	clean := Room{
-		Code:        code,
-		Turn:        raw.Turn,
-		PlayerX:     raw.PlayerX,
-		PlayerO:     raw.PlayerO,
-		PlayerXName: raw.PlayerXName,
-		PlayerOName: raw.PlayerOName,
-		IsPublic:    raw.IsPublic,
-		Winner:      raw.Winner,
-		WinningLine: raw.WinningLine,
-		Status:      raw.Status,
-		WinsX:       raw.WinsX,
-		WinsO:       raw.WinsO,
-		Spectators:  raw.Spectators,
-		GameType:    raw.GameType,
-		ChessState:  raw.ChessState,
-	}
-
-	if clean.GameType == "" {
-		clean.GameType = "tictactoe"
-	}
-
-	if clean.Spectators == nil {
-		clean.Spectators = make(map[string]string)
-	}
-
-	// Fix Code if missing in body
-	if clean.Code == "" {
-		clean.Code = code
-	}
-
-	// Safely convert Board
-	clean.Board = [9]string{" ", " ", " ", " ", " ", " ", " ", " ", " "} // Default empty
-	for i, val := range raw.Board {
-		if i >= 9 {
-			break
-		}
-		// Type assertion to handle strings vs numbers
-		switch v := val.(type) {
-		case string:
-			clean.Board[i] = v
-		case float64: // JSON numbers come as float64
-			clean.Board[i] = fmt.Sprintf("%.0f", v) // Convert 0 -> "0"
-		case int:
-			clean.Board[i] = fmt.Sprintf("%d", v)
-		default:
-			clean.Board[i] = " "
-		}
+		return nil, fmt.Errorf("error initializing db client: %v", err)
 	}
-	return clean
+	return &firebaseStore{client: client, stream: newStreamClient(context.Background())}, nil
 }
 
-func CreateRoom(code, pid, name string, public bool, gameType string) error {
-	ref := client.NewRef("rooms/" + code)
+func (s *firebaseStore) CreateRoom(code, pid, name string, opts RoomOptions) error {
+	ref := s.client.NewRef("rooms/" + code)
 
 	// Check collision
 	var raw rawRoom
 	if err := ref.Get(context.Background(), &raw); err == nil {
 		if raw.PlayerX != "" {
-			return fmt.Errorf("room code taken")
+			return ErrRoomTaken
 		}
 	}
 
-	r := Room{
-		Code:        code,
-		PlayerX:     pid,
-		PlayerXName: name,
-		IsPublic:    public,
-		Status:      "waiting",
-		Spectators:  make(map[string]string),
-		UpdatedAt:   time.Now().Unix(),
-		GameType:    gameType,
-	}
-
-	if gameType == "chess" {
-		r.ChessState = chess.NewGame()
-		r.Turn = "White"
-	} else {
-		r.Board = [9]string{" ", " ", " ", " ", " ", " ", " ", " ", " "}
-		r.Turn = "X"
+	r, err := newRoom(code, pid, name, opts)
+	if err != nil {
+		return err
 	}
 
-	log.Printf("Creating Room: %s (%s)", code, gameType)
+	log.Printf("Creating Room: %s (%s)", code, opts.GameType)
 	return ref.Set(context.Background(), r)
 }
 
-func GetRoom(code string) (*Room, error) {
-	ref := client.NewRef("rooms/" + code)
+func (s *firebaseStore) GetRoom(code string) (*Room, error) {
+	ref := s.client.NewRef("rooms/" + code)
 	// Fetch as Raw first to avoid crashing on bad data
 	var raw rawRoom
 	if err := ref.Get(context.Background(), &raw); err != nil {
 		return nil, err
 	}
 	if raw.PlayerX == "" {
-		return nil, fmt.Errorf("room does not exist")
+		return nil, ErrRoomNotFound
 	}
 
 	clean := sanitizeRoom(code, raw)
 	return &clean, nil
 }
 
-func JoinRoom(code, pid, name string) error {
-	ctx := context.Background()
-
-	// Transaction needs strict type mapping, so if the room is corrupted,
-	// this might still fail unless we handle it inside.
-	// For simplicity, we assume GetRoom checks passed.
-	fn := func(tn db.TransactionNode) (interface{}, error) {
+// transact runs fn against the room inside a Firebase transaction. The room
+// is read loosely and written back sanitized, effectively "fixing" the data,
+// with its Version bumped if fn changed the game or the seats. Errors from
+// fn abort the transaction and are
+// returned as-is, except errUnchanged, which just skips the write.
+func (s *firebaseStore) transact(code string, fn func(r *Room) error) error {
+	err := s.client.NewRef("rooms/"+code).Transaction(context.Background(), func(tn db.TransactionNode) (interface{}, error) {
 		var raw rawRoom
 		if err := tn.Unmarshal(&raw); err != nil {
 			return nil, err
 		}
 		if raw.PlayerX == "" {
-			return nil, fmt.Errorf("room not found")
+			return nil, ErrRoomNotFound
 		}
 
-		// Check if Host is rejoining
-		if raw.PlayerX == pid {
-			raw.PlayerXName = name
-			raw.UpdatedAt = time.Now().Unix()
-			return raw, nil
+		r := sanitizeRoom(code, raw)
+		before := r.playState()
+		if err := fn(&r); err != nil {
+			return nil, err
 		}
-
-		if raw.PlayerO != "" && raw.PlayerO != pid {
-			// Room full -> Join as Spectator
-			if raw.Spectators == nil {
-				raw.Spectators = make(map[string]string)
-			}
-			raw.Spectators[pid] = name
-			return raw, nil
+		if r.playState() != before {
+			r.Version++
 		}
-
-		// Update fields
-		raw.PlayerO = pid
-		raw.PlayerOName = name
-		raw.Status = "playing"
-		return raw, nil
+		return r, nil
+	})
+	if err == errUnchanged {
+		return nil
 	}
-	return client.NewRef("rooms/"+code).Transaction(ctx, fn)
+	return err
 }
 
-func LeaveRoom(code, pid string, isHost bool) error {
-	ctx := context.Background()
-	ref := client.NewRef("rooms/" + code)
+func (s *firebaseStore) JoinRoom(code, pid, name string) error {
+	err := s.transact(code, func(r *Room) error {
+		r.join(pid, name)
+		return nil
+	})
+	if err == ErrRoomNotFound {
+		return fmt.Errorf("room not found")
+	}
+	return err
+}
 
+func (s *firebaseStore) LeaveRoom(code, pid string, isHost bool) error {
 	if isHost {
 		// Host leaves -> Delete room
-		return ref.Delete(ctx)
-	}
-
-	// Not host. Check if PlayerO or Spectator
-	// We need to fetch current state to know role, but here we passed isHost.
-	// Actually we should fetch first to be safe, or just try to remove from both.
-	// Firebase update paths:
-	// If O: update playerO=""
-	// If Spectator: delete spectators/pid
-
-	// Let's use transaction to be safe and atomic
-	fn := func(tn db.TransactionNode) (interface{}, error) {
-		var raw rawRoom
-		if err := tn.Unmarshal(&raw); err != nil {
-			return nil, err
-		}
-
-		if raw.PlayerO == pid {
-			raw.PlayerO = ""
-			raw.PlayerOName = ""
-			raw.Status = "waiting"
-		} else {
-			if raw.Spectators != nil {
-				delete(raw.Spectators, pid)
-			}
+		if r, err := s.GetRoom(code); err == nil {
+			s.archive(*r)
 		}
-		return raw, nil
+		return s.client.NewRef("rooms/" + code).Delete(context.Background())
 	}
-	return ref.Transaction(ctx, fn)
-}
 
-func UpdateMove(code, pid string, idx int, r Room) error {
-	// Game Logic
-	r.Board[idx] = r.Turn
-	winner, line := tictactoe.CheckWinner(r.Board)
-
-	if winner != "" {
-		r.Winner = winner
-		r.WinningLine = line
-		r.Status = "finished"
-		if winner == "X" {
-			r.WinsX++
-		} else {
-			r.WinsO++
-		}
-	} else if tictactoe.CheckDraw(r.Board) {
-		r.Status = "finished"
-	} else {
-		if r.Turn == "X" {
-			r.Turn = "O"
-		} else {
-			r.Turn = "X"
-		}
+	// Not host, so PlayerO or a Spectator.
+	// Use a transaction to be safe and atomic.
+	err := s.transact(code, func(r *Room) error {
+		r.leave(pid)
+		return nil
+	})
+	if err == ErrRoomNotFound {
+		// Already gone
+		return nil
 	}
-
-	// When saving back, we save strict Room, effectively "fixing" the data
-	return client.NewRef("rooms/"+code).Set(context.Background(), r)
+	return err
 }
 
-func UpdateChessState(code string, state chess.GameState) error {
-	ref := client.NewRef("rooms/" + code)
-	fn := func(tn db.TransactionNode) (interface{}, error) {
-		var r Room
-		if err := tn.Unmarshal(&r); err != nil {
-			return nil, err
-		}
-		r.ChessState = state
-		r.Turn = state.Turn
-		if state.Status != "playing" {
-			r.Status = state.Status
-			r.Winner = state.Winner
-		}
-		r.UpdatedAt = time.Now().Unix()
-		return r, nil
-	}
-	return ref.Transaction(context.Background(), fn)
+func (s *firebaseStore) SubmitMove(code, pid, move string, version int64) error {
+	return s.transact(code, func(r *Room) error {
+		return r.play(pid, move, version)
+	})
 }
 
-func RestartGame(code string, nextTurn string) error {
-	ctx := context.Background()
-	ref := client.NewRef("rooms/" + code)
-	fn := func(tn db.TransactionNode) (interface{}, error) {
-		var r Room
-		if err := tn.Unmarshal(&r); err != nil {
-			return nil, err
-		}
-
-		if r.GameType == "chess" {
-			r.ChessState = chess.NewGame()
-			// Map X/O to White/Black if needed, or rely on caller
-			if nextTurn == "X" {
-				nextTurn = "White"
-			}
-			if nextTurn == "O" {
-				nextTurn = "Black"
-			}
-			r.Turn = nextTurn
-			r.ChessState.Turn = nextTurn // Sync
-		} else {
-
-			r.Board = [9]string{" ", " ", " ", " ", " ", " ", " ", " ", " "}
-			r.Turn = nextTurn
-		}
+func (s *firebaseStore) CheckFlag(code string) error {
+	return s.transact(code, func(r *Room) error {
+		return r.checkFlag()
+	})
+}
 
-		r.Winner = ""
-		r.WinningLine = nil
-		r.Status = "playing"
-		return r, nil
-	}
-	return ref.Transaction(ctx, fn)
+func (s *firebaseStore) RestartGame(code, pid, nextTurn string, version int64) error {
+	return s.transact(code, func(r *Room) error {
+		return r.restart(pid, nextTurn, version)
+	})
 }
 
-func GetPublicRooms() ([]Room, error) {
-	ref := client.NewRef("rooms")
+func (s *firebaseStore) GetPublicRooms() ([]Room, error) {
+	ref := s.client.NewRef("rooms")
 
 	// 1. Fetch as map of RawRooms (tolerant to bad data)
 	var rawMap map[string]rawRoom
@@ -374,9 +192,42 @@ func GetPublicRooms() ([]Room, error) {
 	return list, nil
 }
 
-// CleanZombies removes rooms that haven't been updated in 1 hour
-func CleanZombies() {
-	ref := client.NewRef("rooms")
+// archive keeps the record of a room about to be deleted under "archive/"
+func (s *firebaseStore) archive(r Room) {
+	if !r.worthArchiving() {
+		return
+	}
+	record, _ := r.Record()
+	if err := s.client.NewRef("archive/"+r.Code).Set(context.Background(), record); err != nil {
+		log.Printf("Error archiving room %s: %v", r.Code, err)
+	}
+}
+
+func (s *firebaseStore) GetRecord(code string) (string, error) {
+	r, err := s.GetRoom(code)
+	if err == nil {
+		record, ok := r.Record()
+		if !ok {
+			return "", ErrNoRecord
+		}
+		return record, nil
+	}
+	if err != ErrRoomNotFound {
+		return "", err
+	}
+
+	var record string
+	if err := s.client.NewRef("archive/"+code).Get(context.Background(), &record); err != nil {
+		return "", err
+	}
+	if record == "" {
+		return "", ErrRoomNotFound
+	}
+	return record, nil
+}
+
+func (s *firebaseStore) CleanZombies() {
+	ref := s.client.NewRef("rooms")
 	var rawMap map[string]rawRoom
 	if err := ref.Get(context.Background(), &rawMap); err != nil {
 		log.Printf("Janitor: Error fetching rooms: %v", err)
@@ -384,12 +235,61 @@ func CleanZombies() {
 	}
 
 	now := time.Now().Unix()
-	limit := int64(3600) // 1 hour
 
 	for code, r := range rawMap {
-		if now-r.UpdatedAt > limit {
+		if now-r.UpdatedAt > zombieLimit {
 			log.Printf("Janitor: Deleting zombie room %s (Last active: %ds ago)", code, now-r.UpdatedAt)
+			s.archive(sanitizeRoom(code, r))
 			ref.Child(code).Delete(context.Background())
 		}
 	}
 }
+
+// SubmitScore keeps each player's best under "scores/<board>/<pid>"
+func (s *firebaseStore) SubmitScore(board, pid, name string, score int, replay string) error {
+	ref := s.client.NewRef("scores/" + board + "/" + pid)
+	return ref.Transaction(context.Background(), func(tn db.TransactionNode) (interface{}, error) {
+		var old game.Score
+		if err := tn.Unmarshal(&old); err != nil {
+			return nil, err
+		}
+		best, _ := bestScore(old, old.ID != "", pid, name, score, replay)
+		return best, nil
+	})
+}
+
+func (s *firebaseStore) GetLeaderboard(board, pid string, n int) (game.Leaderboard, error) {
+	// Boards are small enough to rank here, which also spares the
+	// database an index on score
+	var byPlayer map[string]game.Score
+	if err := s.client.NewRef("scores/"+board).Get(context.Background(), &byPlayer); err != nil {
+		log.Printf("Error fetching leaderboard %s: %v", board, err)
+		return game.Leaderboard{}, err
+	}
+	scores := make([]game.Score, 0, len(byPlayer))
+	for _, sc := range byPlayer {
+		scores = append(scores, sc)
+	}
+	return rankScores(scores, pid, n), nil
+}
+
+// SaveItem keeps pid's items under "library/<pid>/<kind>/<name>"
+func (s *firebaseStore) SaveItem(pid, kind, name, data string) error {
+	if err := CheckItemName(name); err != nil {
+		return err
+	}
+	return s.client.NewRef("library/"+pid+"/"+kind+"/"+name).Set(context.Background(), data)
+}
+
+func (s *firebaseStore) GetItems(pid, kind string) (map[string]string, error) {
+	items := make(map[string]string)
+	if err := s.client.NewRef("library/"+pid+"/"+kind).Get(context.Background(), &items); err != nil {
+		log.Printf("Error fetching %s library of %s: %v", kind, pid, err)
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *firebaseStore) DeleteItem(pid, kind, name string) error {
+	return s.client.NewRef("library/" + pid + "/" + kind + "/" + name).Delete(context.Background())
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/config"
+	"golang.org/x/oauth2/google"
+)
+
+// The Go Admin SDK has no realtime listener, so rooms are watched through
+// the REST streaming API (server-sent events) instead. Events only tell us
+// *that* the room changed; we then read it once with GetRoom, which keeps
+// the sanitizing in one place.
+
+var streamScopes = []string{
+	"https://www.googleapis.com/auth/firebase.database",
+	"https://www.googleapis.com/auth/userinfo.email",
+}
+
+// streamRetry is how long to wait before reopening a dropped stream
+const streamRetry = 2 * time.Second
+
+// newStreamClient builds an HTTP client authorized for the database. Without
+// credentials it falls back to a plain client, which works for open rules.
+func newStreamClient(ctx context.Context) *http.Client {
+	var creds *google.Credentials
+	var err error
+	if config.CredPath != "" {
+		var data []byte
+		if data, err = os.ReadFile(config.CredPath); err == nil {
+			creds, err = google.CredentialsFromJSON(ctx, data, streamScopes...)
+		}
+	} else {
+		creds, err = google.FindDefaultCredentials(ctx, streamScopes...)
+	}
+	if err != nil || creds == nil {
+		log.Printf("Room streams unauthenticated: %v", err)
+		return &http.Client{}
+	}
+	return &http.Client{Transport: &oauthTransport{src: creds}}
+}
+
+// oauthTransport adds a bearer token to every request, redirects included
+type oauthTransport struct {
+	src *google.Credentials
+}
+
+func (t *oauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tok, err := t.src.TokenSource.Token()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	tok.SetAuthHeader(req)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func (s *firebaseStore) Watch(code string, fn func(r *Room)) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for ctx.Err() == nil {
+			err := s.listen(ctx, code, fn)
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Room stream %s dropped: %v", code, err)
+
+			// Poll while the stream is down so nothing is missed
+			s.emit(code, fn)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(streamRetry):
+			}
+		}
+	}()
+	return cancel
+}
+
+// listen holds one event stream open until it ends or ctx is cancelled
+func (s *firebaseStore) listen(ctx context.Context, code string, fn func(r *Room)) error {
+	url := strings.TrimSuffix(config.DBURL, "/") + "/rooms/" + code + ".json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := s.stream.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("stream status %s", resp.Status)
+	}
+
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	event := ""
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case line == "":
+			// End of one event
+			switch event {
+			case "put", "patch":
+				s.emit(code, fn)
+			case "cancel", "auth_revoked":
+				return fmt.Errorf("stream %s", event)
+			}
+			event = ""
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	return errors.New("stream closed")
+}
+
+func (s *firebaseStore) emit(code string, fn func(r *Room)) {
+	r, err := s.GetRoom(code)
+	if errors.Is(err, ErrRoomNotFound) {
+		fn(nil)
+		return
+	}
+	if err != nil {
+		log.Printf("Room stream %s: read failed: %v", code, err)
+		return
+	}
+	fn(r)
+}
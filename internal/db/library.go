@@ -0,0 +1,27 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxItemName is how long the name of a saved item may be
+const maxItemName = 24
+
+// CheckItemName rejects names that can't be stored as keys in every
+// backend. Letters, digits, spaces, '-' and '_' are fine.
+func CheckItemName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("name is empty")
+	}
+	if len(name) > maxItemName {
+		return fmt.Errorf("name is longer than %d characters", maxItemName)
+	}
+	for _, c := range name {
+		ok := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == ' ' || c == '-' || c == '_'
+		if !ok {
+			return fmt.Errorf("name can't contain %q", c)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,384 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// roomTx is a view of the rooms table inside one transaction
+type roomTx interface {
+	get(code string) (Room, bool, error)
+	put(r Room) error
+	del(code string) error
+	each(fn func(r Room) error) error
+	// archive keeps the record of a deleted room's game, keyed by room code
+	archive(code, record string) error
+	archived(code string) (string, bool, error)
+	// scores keep every player's best on each leaderboard
+	score(board, pid string) (game.Score, bool, error)
+	putScore(board string, sc game.Score) error
+	scores(board string) ([]game.Score, error)
+	// items keep what each player has saved, by kind and name
+	items(pid, kind string) (map[string]string, error)
+	putItem(pid, kind, name, data string) error
+	delItem(pid, kind, name string) error
+}
+
+// roomKV is the transactional key/value surface the local backends provide.
+// update must be atomic: either everything fn wrote lands, or nothing does.
+type roomKV interface {
+	view(fn func(tx roomTx) error) error
+	update(fn func(tx roomTx) error) error
+}
+
+// localStore implements Store on top of a roomKV, so the in-memory and bolt
+// backends share the exact same room semantics. Since every write goes
+// through this process, it can also notify watchers directly.
+type localStore struct {
+	kv roomKV
+
+	mu       sync.Mutex
+	watchers map[string]map[int]func(r *Room)
+	nextID   int
+}
+
+// touchTx records which rooms a transaction wrote so watchers can be told
+type touchTx struct {
+	roomTx
+	touched map[string]bool
+}
+
+func (tx touchTx) put(r Room) error {
+	tx.touched[r.Code] = true
+	return tx.roomTx.put(r)
+}
+
+func (tx touchTx) del(code string) error {
+	tx.touched[code] = true
+	return tx.roomTx.del(code)
+}
+
+// update wraps kv.update and notifies watchers of every room it changed
+func (s *localStore) update(fn func(tx roomTx) error) error {
+	touched := make(map[string]bool)
+	err := s.kv.update(func(tx roomTx) error {
+		return fn(touchTx{roomTx: tx, touched: touched})
+	})
+	if err != nil {
+		return err
+	}
+	for code := range touched {
+		s.notify(code)
+	}
+	return nil
+}
+
+func (s *localStore) Watch(code string, fn func(r *Room)) (stop func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.watchers == nil {
+		s.watchers = make(map[string]map[int]func(r *Room))
+	}
+	if s.watchers[code] == nil {
+		s.watchers[code] = make(map[int]func(r *Room))
+	}
+	s.nextID++
+	id := s.nextID
+	s.watchers[code][id] = fn
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.watchers[code], id)
+		if len(s.watchers[code]) == 0 {
+			delete(s.watchers, code)
+		}
+	}
+}
+
+func (s *localStore) notify(code string) {
+	s.mu.Lock()
+	var fns []func(r *Room)
+	for _, fn := range s.watchers[code] {
+		fns = append(fns, fn)
+	}
+	s.mu.Unlock()
+	if len(fns) == 0 {
+		return
+	}
+
+	r, err := s.GetRoom(code)
+	if err != nil {
+		r = nil
+	}
+	for _, fn := range fns {
+		fn(r)
+	}
+}
+
+func encodeRoom(r Room) ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func decodeRoom(b []byte) (Room, error) {
+	var raw rawRoom
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return Room{}, err
+	}
+	return sanitizeRoom(raw.Code, raw), nil
+}
+
+// mutate runs fn against the stored room and writes the result back in the
+// same transaction, with its Version bumped if fn changed the game or the
+// seats. If fn returns errUnchanged nothing is written.
+func (s *localStore) mutate(code string, fn func(r *Room) error) error {
+	err := s.update(func(tx roomTx) error {
+		r, ok, err := tx.get(code)
+		if err != nil {
+			return err
+		}
+		if !ok || r.PlayerX == "" {
+			return ErrRoomNotFound
+		}
+		before := r.playState()
+		if err := fn(&r); err != nil {
+			return err
+		}
+		if r.playState() != before {
+			r.Version++
+		}
+		return tx.put(r)
+	})
+	if err == errUnchanged {
+		return nil
+	}
+	return err
+}
+
+func (s *localStore) CreateRoom(code, pid, name string, opts RoomOptions) error {
+	r, err := newRoom(code, pid, name, opts)
+	if err != nil {
+		return err
+	}
+
+	return s.update(func(tx roomTx) error {
+		// Check collision
+		if r, ok, err := tx.get(code); err != nil {
+			return err
+		} else if ok && r.PlayerX != "" {
+			return ErrRoomTaken
+		}
+
+		log.Printf("Creating Room: %s (%s)", code, opts.GameType)
+		return tx.put(r)
+	})
+}
+
+func (s *localStore) GetRoom(code string) (*Room, error) {
+	var room *Room
+	err := s.kv.view(func(tx roomTx) error {
+		r, ok, err := tx.get(code)
+		if err != nil {
+			return err
+		}
+		if !ok || r.PlayerX == "" {
+			return ErrRoomNotFound
+		}
+		room = &r
+		return nil
+	})
+	return room, err
+}
+
+func (s *localStore) JoinRoom(code, pid, name string) error {
+	err := s.mutate(code, func(r *Room) error {
+		r.join(pid, name)
+		return nil
+	})
+	if err == ErrRoomNotFound {
+		return fmt.Errorf("room not found")
+	}
+	return err
+}
+
+// deleteRoom removes a room, archiving the record of its game first
+func deleteRoom(tx roomTx, code string) error {
+	r, ok, err := tx.get(code)
+	if err != nil {
+		return err
+	}
+	if ok && r.worthArchiving() {
+		record, _ := r.Record()
+		if err := tx.archive(code, record); err != nil {
+			return err
+		}
+	}
+	return tx.del(code)
+}
+
+func (s *localStore) LeaveRoom(code, pid string, isHost bool) error {
+	if isHost {
+		// Host leaves -> Delete room
+		return s.update(func(tx roomTx) error {
+			return deleteRoom(tx, code)
+		})
+	}
+
+	err := s.mutate(code, func(r *Room) error {
+		r.leave(pid)
+		return nil
+	})
+	if err == ErrRoomNotFound {
+		// Already gone
+		return nil
+	}
+	return err
+}
+
+func (s *localStore) SubmitMove(code, pid, move string, version int64) error {
+	return s.mutate(code, func(r *Room) error {
+		return r.play(pid, move, version)
+	})
+}
+
+func (s *localStore) CheckFlag(code string) error {
+	return s.mutate(code, func(r *Room) error {
+		return r.checkFlag()
+	})
+}
+
+func (s *localStore) RestartGame(code, pid, nextTurn string, version int64) error {
+	return s.mutate(code, func(r *Room) error {
+		return r.restart(pid, nextTurn, version)
+	})
+}
+
+func (s *localStore) GetPublicRooms() ([]Room, error) {
+	var list []Room
+	err := s.kv.view(func(tx roomTx) error {
+		return tx.each(func(r Room) error {
+			if r.IsPublic && r.PlayerX != "" {
+				list = append(list, r)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		log.Printf("Error fetching public rooms: %v", err)
+		return nil, err
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Code < list[j].Code
+	})
+
+	return list, nil
+}
+
+func (s *localStore) GetRecord(code string) (string, error) {
+	var record string
+	err := s.kv.view(func(tx roomTx) error {
+		r, ok, err := tx.get(code)
+		if err != nil {
+			return err
+		}
+		if ok && r.PlayerX != "" {
+			if record, ok = r.Record(); !ok {
+				return ErrNoRecord
+			}
+			return nil
+		}
+
+		record, ok, err = tx.archived(code)
+		if err == nil && !ok {
+			err = ErrRoomNotFound
+		}
+		return err
+	})
+	return record, err
+}
+
+func (s *localStore) CleanZombies() {
+	now := time.Now().Unix()
+	err := s.update(func(tx roomTx) error {
+		var zombies []string
+		err := tx.each(func(r Room) error {
+			if now-r.UpdatedAt > zombieLimit {
+				log.Printf("Janitor: Deleting zombie room %s (Last active: %ds ago)", r.Code, now-r.UpdatedAt)
+				zombies = append(zombies, r.Code)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, code := range zombies {
+			if err := deleteRoom(tx, code); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Janitor: Error cleaning rooms: %v", err)
+	}
+}
+
+func (s *localStore) SubmitScore(board, pid, name string, score int, replay string) error {
+	return s.kv.update(func(tx roomTx) error {
+		old, had, err := tx.score(board, pid)
+		if err != nil {
+			return err
+		}
+		best, changed := bestScore(old, had, pid, name, score, replay)
+		if !changed {
+			return nil
+		}
+		return tx.putScore(board, best)
+	})
+}
+
+func (s *localStore) GetLeaderboard(board, pid string, n int) (game.Leaderboard, error) {
+	var lb game.Leaderboard
+	err := s.kv.view(func(tx roomTx) error {
+		scores, err := tx.scores(board)
+		if err != nil {
+			return err
+		}
+		lb = rankScores(scores, pid, n)
+		return nil
+	})
+	return lb, err
+}
+
+func (s *localStore) SaveItem(pid, kind, name, data string) error {
+	if err := CheckItemName(name); err != nil {
+		return err
+	}
+	return s.kv.update(func(tx roomTx) error {
+		return tx.putItem(pid, kind, name, data)
+	})
+}
+
+func (s *localStore) GetItems(pid, kind string) (map[string]string, error) {
+	var items map[string]string
+	err := s.kv.view(func(tx roomTx) error {
+		var err error
+		items, err = tx.items(pid, kind)
+		return err
+	})
+	return items, err
+}
+
+func (s *localStore) DeleteItem(pid, kind, name string) error {
+	return s.kv.update(func(tx roomTx) error {
+		return tx.delItem(pid, kind, name)
+	})
+}
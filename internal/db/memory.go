@@ -0,0 +1,223 @@
+package db
+
+import (
+	"sync"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// memoryKV keeps rooms in process memory. Nothing survives a restart,
+// which makes it a good fit for local hacking and for faking the backend.
+// Rooms are kept encoded so every read hands out a fresh copy, the same
+// way a room written to Firebase comes back on the next read.
+type memoryKV struct {
+	mu       sync.RWMutex
+	rooms    map[string][]byte
+	archives map[string]string
+	scores   map[scoreKey]game.Score
+	library  map[itemKey]string
+}
+
+// scoreKey is a player's place on one leaderboard
+type scoreKey struct {
+	board, pid string
+}
+
+// itemKey is one thing a player has saved
+type itemKey struct {
+	pid, kind, name string
+}
+
+// NewMemoryStore returns an empty in-memory Store
+func NewMemoryStore() Store {
+	return &localStore{kv: &memoryKV{
+		rooms:    make(map[string][]byte),
+		archives: make(map[string]string),
+		scores:   make(map[scoreKey]game.Score),
+		library:  make(map[itemKey]string),
+	}}
+}
+
+func (kv *memoryKV) view(fn func(tx roomTx) error) error {
+	kv.mu.RLock()
+	defer kv.mu.RUnlock()
+	return fn(&memoryTx{rooms: kv.rooms, archives: kv.archives, scoreMap: kv.scores, library: kv.library})
+}
+
+func (kv *memoryKV) update(fn func(tx roomTx) error) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	// Stage writes so a failing fn leaves the map untouched
+	tx := &memoryTx{
+		rooms:         kv.rooms,
+		writes:        make(map[string][]byte),
+		archives:      kv.archives,
+		archiveWrites: make(map[string]string),
+		scoreMap:      kv.scores,
+		scoreWrites:   make(map[scoreKey]game.Score),
+		library:       kv.library,
+		itemWrites:    make(map[itemKey]*string),
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	for code, record := range tx.archiveWrites {
+		kv.archives[code] = record
+	}
+	for k, sc := range tx.scoreWrites {
+		kv.scores[k] = sc
+	}
+	for k, data := range tx.itemWrites {
+		if data == nil {
+			delete(kv.library, k)
+		} else {
+			kv.library[k] = *data
+		}
+	}
+	for code, b := range tx.writes {
+		if b == nil {
+			delete(kv.rooms, code)
+		} else {
+			kv.rooms[code] = b
+		}
+	}
+	return nil
+}
+
+type memoryTx struct {
+	rooms  map[string][]byte
+	writes map[string][]byte // nil value = delete; nil map = read-only
+
+	archives      map[string]string
+	archiveWrites map[string]string
+
+	scoreMap    map[scoreKey]game.Score
+	scoreWrites map[scoreKey]game.Score
+
+	library    map[itemKey]string
+	itemWrites map[itemKey]*string // nil value = delete
+}
+
+func (tx *memoryTx) lookup(code string) ([]byte, bool) {
+	if b, ok := tx.writes[code]; ok {
+		return b, b != nil
+	}
+	b, ok := tx.rooms[code]
+	return b, ok
+}
+
+func (tx *memoryTx) get(code string) (Room, bool, error) {
+	b, ok := tx.lookup(code)
+	if !ok {
+		return Room{}, false, nil
+	}
+	r, err := decodeRoom(b)
+	return r, err == nil, err
+}
+
+func (tx *memoryTx) put(r Room) error {
+	b, err := encodeRoom(r)
+	if err != nil {
+		return err
+	}
+	tx.writes[r.Code] = b
+	return nil
+}
+
+func (tx *memoryTx) del(code string) error {
+	tx.writes[code] = nil
+	return nil
+}
+
+func (tx *memoryTx) each(fn func(r Room) error) error {
+	for code := range tx.rooms {
+		if _, staged := tx.writes[code]; staged {
+			continue
+		}
+		if err := tx.eachOne(code, fn); err != nil {
+			return err
+		}
+	}
+	for code := range tx.writes {
+		if err := tx.eachOne(code, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tx *memoryTx) eachOne(code string, fn func(r Room) error) error {
+	r, ok, err := tx.get(code)
+	if err != nil || !ok {
+		return err
+	}
+	return fn(r)
+}
+
+func (tx *memoryTx) archive(code, record string) error {
+	tx.archiveWrites[code] = record
+	return nil
+}
+
+func (tx *memoryTx) archived(code string) (string, bool, error) {
+	if record, ok := tx.archiveWrites[code]; ok {
+		return record, true, nil
+	}
+	record, ok := tx.archives[code]
+	return record, ok, nil
+}
+
+func (tx *memoryTx) score(board, pid string) (game.Score, bool, error) {
+	k := scoreKey{board, pid}
+	if sc, ok := tx.scoreWrites[k]; ok {
+		return sc, true, nil
+	}
+	sc, ok := tx.scoreMap[k]
+	return sc, ok, nil
+}
+
+func (tx *memoryTx) putScore(board string, sc game.Score) error {
+	tx.scoreWrites[scoreKey{board, sc.ID}] = sc
+	return nil
+}
+
+func (tx *memoryTx) scores(board string) ([]game.Score, error) {
+	var list []game.Score
+	for k, sc := range tx.scoreMap {
+		if _, staged := tx.scoreWrites[k]; k.board == board && !staged {
+			list = append(list, sc)
+		}
+	}
+	for k, sc := range tx.scoreWrites {
+		if k.board == board {
+			list = append(list, sc)
+		}
+	}
+	return list, nil
+}
+
+func (tx *memoryTx) items(pid, kind string) (map[string]string, error) {
+	items := make(map[string]string)
+	for k, data := range tx.library {
+		if _, staged := tx.itemWrites[k]; k.pid == pid && k.kind == kind && !staged {
+			items[k.name] = data
+		}
+	}
+	for k, data := range tx.itemWrites {
+		if k.pid == pid && k.kind == kind && data != nil {
+			items[k.name] = *data
+		}
+	}
+	return items, nil
+}
+
+func (tx *memoryTx) putItem(pid, kind, name, data string) error {
+	tx.itemWrites[itemKey{pid, kind, name}] = &data
+	return nil
+}
+
+func (tx *memoryTx) delItem(pid, kind, name string) error {
+	tx.itemWrites[itemKey{pid, kind, name}] = nil
+	return nil
+}
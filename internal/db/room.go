@@ -0,0 +1,433 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// zombieLimit is how long a room may sit untouched before CleanZombies removes it
+const zombieLimit = int64(3600) // 1 hour
+
+// Room is the clean, strict structure used by the Game UI
+type Room struct {
+	Code string `json:"code"`
+	// State is the game itself, stored by its Rules; see GameState
+	State       json.RawMessage   `json:"state"`
+	Turn        string            `json:"turn"` // Seat to move
+	PlayerX     string            `json:"playerX"`
+	PlayerO     string            `json:"playerO"`
+	PlayerXName string            `json:"playerXName"`
+	PlayerOName string            `json:"playerOName"`
+	IsPublic    bool              `json:"isPublic"`
+	Winner      string            `json:"winner"` // Seat that won the last game, "" for a draw
+	Reason      string            `json:"reason"` // Why the last game ended, if the game says
+	Status      string            `json:"status"`
+	WinsX       int               `json:"winsX"`
+	WinsO       int               `json:"winsO"`
+	Spectators  map[string]string `json:"spectators"`
+	UpdatedAt   int64             `json:"updatedAt"`
+	GameType    string            `json:"gameType"`
+	StartedAt   int64             `json:"startedAt"` // When the current game began
+	Clock       game.Clock        `json:"clock"`     // Time control; zero = untimed
+	// Version goes up by one on every write that changes the game or who
+	// is seated, so a move made against an old snapshot can be told apart
+	// and rejected. Spectators coming and going leave it alone.
+	Version int64 `json:"version"`
+}
+
+// rawRoom is a helper struct to safely read dirty data (mixed types) from Firebase
+type rawRoom struct {
+	Code        string            `json:"code"`
+	State       json.RawMessage   `json:"state"`
+	Turn        string            `json:"turn"`
+	PlayerX     string            `json:"playerX"`
+	PlayerO     string            `json:"playerO"`
+	PlayerXName string            `json:"playerXName"`
+	PlayerOName string            `json:"playerOName"`
+	IsPublic    bool              `json:"isPublic"`
+	Winner      string            `json:"winner"`
+	Reason      string            `json:"reason"`
+	Status      string            `json:"status"`
+	WinsX       int               `json:"winsX"`
+	WinsO       int               `json:"winsO"`
+	Spectators  map[string]string `json:"spectators"`
+	UpdatedAt   int64             `json:"updatedAt"`
+	GameType    string            `json:"gameType"`
+	StartedAt   int64             `json:"startedAt"`
+	Clock       game.Clock        `json:"clock"`
+	Version     int64             `json:"version"`
+}
+
+// Helper to convert raw data to clean Room
+func sanitizeRoom(code string, raw rawRoom) Room {
+	clean := Room{
+		Code:        code,
+		State:       raw.State,
+		Turn:        raw.Turn,
+		PlayerX:     raw.PlayerX,
+		PlayerO:     raw.PlayerO,
+		PlayerXName: raw.PlayerXName,
+		PlayerOName: raw.PlayerOName,
+		IsPublic:    raw.IsPublic,
+		Winner:      raw.Winner,
+		Reason:      raw.Reason,
+		Status:      raw.Status,
+		WinsX:       raw.WinsX,
+		WinsO:       raw.WinsO,
+		Spectators:  raw.Spectators,
+		UpdatedAt:   raw.UpdatedAt,
+		GameType:    raw.GameType,
+		StartedAt:   raw.StartedAt,
+		Clock:       raw.Clock,
+		Version:     raw.Version,
+	}
+
+	if clean.GameType == "" {
+		clean.GameType = "tictactoe"
+	}
+
+	if clean.Spectators == nil {
+		clean.Spectators = make(map[string]string)
+	}
+
+	// Fix Code if missing in body
+	if clean.Code == "" {
+		clean.Code = code
+	}
+
+	// A game this server can't read, e.g. from before it was stored this
+	// way, starts over
+	if g := clean.Game(); g != nil && g.Rules != nil {
+		if _, err := g.Rules.Decode(clean.State); err != nil {
+			if state, err := g.Rules.New(nil); err == nil {
+				clean.State, _ = json.Marshal(state)
+				clean.Turn = state.ToMove()
+			}
+		}
+	}
+	return clean
+}
+
+// Game returns the room's game from the registry, or nil if this server
+// doesn't host it
+func (r Room) Game() *game.Game {
+	return game.Lookup(r.GameType)
+}
+
+// Open reports whether someone else can join the room to play: its second
+// seat is free, or it runs a live game, which players join in the game
+func (r Room) Open() bool {
+	if g := r.Game(); g != nil && g.NewLive != nil {
+		return true
+	}
+	return r.PlayerO == ""
+}
+
+// GameState reads the room's game, or returns nil if there is none
+func (r Room) GameState() game.State {
+	g := r.Game()
+	if g == nil || g.Rules == nil {
+		return nil
+	}
+	state, err := g.Rules.Decode(r.State)
+	if err != nil {
+		return nil
+	}
+	return state
+}
+
+// SetState stores state as the room's game and brings Turn up to date. A
+// game that has just ended is scored.
+func (r *Room) SetState(state game.State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	r.State = data
+	r.Turn = state.ToMove()
+	if out := state.Outcome(); out.Over && r.Status != "finished" {
+		r.finish(out)
+	}
+	r.UpdatedAt = time.Now().Unix()
+	return nil
+}
+
+// finish ends the current game with out
+func (r *Room) finish(out game.Outcome) {
+	r.Status = "finished"
+	r.Winner = out.Winner
+	r.Reason = out.Reason
+	switch out.Winner {
+	case game.X:
+		r.WinsX++
+	case game.O:
+		r.WinsO++
+	}
+}
+
+// Info describes the room to its game's Screen
+func (r Room) Info() game.Info {
+	started := r.StartedAt
+	if started == 0 {
+		started = r.UpdatedAt
+	}
+	return game.Info{
+		Code:      r.Code,
+		NameX:     r.PlayerXName,
+		NameO:     r.PlayerOName,
+		WinsX:     r.WinsX,
+		WinsO:     r.WinsO,
+		Status:    r.Status,
+		Winner:    r.Winner,
+		Reason:    r.Reason,
+		Clock:     r.Clock,
+		StartedAt: started,
+	}
+}
+
+// For returns the room as pid may see it. A game with secrets is redacted
+// to pid's seat, so players only see their own and spectators neither.
+func (r Room) For(pid string) Room {
+	secret, ok := r.GameState().(game.Secret)
+	if !ok {
+		return r
+	}
+	data, err := json.Marshal(secret.Redact(r.seatOf(pid)))
+	if err != nil {
+		// Better to show nothing than everything
+		data = nil
+	}
+	r.State = data
+	return r
+}
+
+// --- Room mutations ---
+// These hold the game rules shared by every backend. Each backend runs them
+// inside whatever transaction primitive it has.
+
+func newRoom(code, pid, name string, opts RoomOptions) (Room, error) {
+	g := game.Lookup(opts.GameType)
+	if g == nil || (g.Rules == nil && g.NewLive == nil) {
+		return Room{}, fmt.Errorf("%q is not a room game", opts.GameType)
+	}
+
+	r := Room{
+		Code:        code,
+		PlayerX:     pid,
+		PlayerXName: name,
+		IsPublic:    opts.Public,
+		Status:      "waiting",
+		Spectators:  make(map[string]string),
+		GameType:    opts.GameType,
+		StartedAt:   time.Now().Unix(),
+		// Stopped until the guest arrives
+		Clock:   game.NewClock(opts.TimeControl),
+		Version: 1,
+	}
+	if g.Rules == nil {
+		// A live game runs on the server; the room only says who is in it
+		r.UpdatedAt = time.Now().Unix()
+		return r, nil
+	}
+	state, err := g.Rules.New(opts.Variant)
+	if err != nil {
+		return Room{}, err
+	}
+	if err := r.SetState(state); err != nil {
+		return Room{}, err
+	}
+	return r, nil
+}
+
+func (r *Room) join(pid, name string) {
+	// Check if Host is rejoining
+	if r.PlayerX == pid {
+		r.PlayerXName = name
+		r.UpdatedAt = time.Now().Unix()
+		return
+	}
+
+	if r.PlayerO != "" && r.PlayerO != pid {
+		// Room full -> Join as Spectator
+		if r.Spectators == nil {
+			r.Spectators = make(map[string]string)
+		}
+		r.Spectators[pid] = name
+		return
+	}
+
+	r.PlayerO = pid
+	r.PlayerOName = name
+	r.Status = "playing"
+	if state := r.GameState(); state != nil && !state.Outcome().Over {
+		r.Clock.Start(time.Now())
+	}
+}
+
+// leave removes a guest or spectator. Hosts delete the whole room instead.
+func (r *Room) leave(pid string) {
+	if r.PlayerO == pid {
+		r.PlayerO = ""
+		r.PlayerOName = ""
+		r.Status = "waiting"
+		// Nobody's clock runs while a seat is empty
+		r.Clock.Stop(r.Turn, time.Now())
+	} else if r.Spectators != nil {
+		delete(r.Spectators, pid)
+	}
+}
+
+// seatOf returns "X" for the host, "O" for the guest and "" for anyone else
+func (r *Room) seatOf(pid string) string {
+	switch pid {
+	case "":
+		return ""
+	case r.PlayerX:
+		return game.X
+	case r.PlayerO:
+		return game.O
+	}
+	return ""
+}
+
+// playState is the part of a room a move is made against: the game and the
+// seats around it
+type playState struct {
+	state, turn, gameType string
+	playerX, playerO      string
+	status, winner        string
+	startedAt             int64
+}
+
+func (r *Room) playState() playState {
+	return playState{
+		state: string(r.State), turn: r.Turn, gameType: r.GameType,
+		playerX: r.PlayerX, playerO: r.PlayerO,
+		status: r.Status, winner: r.Winner,
+		startedAt: r.StartedAt,
+	}
+}
+
+// checkVersion rejects a move made against a snapshot older than the room
+func (r *Room) checkVersion(seen int64) error {
+	if seen != r.Version {
+		return rejectMove(ErrStaleRoom, "move was made on version %d, room is at %d", seen, r.Version)
+	}
+	return nil
+}
+
+// play checks move against the stored game and applies it for pid
+func (r *Room) play(pid, move string, seen int64) error {
+	if err := r.checkVersion(seen); err != nil {
+		return err
+	}
+	if r.Status != "playing" {
+		return rejectMove(ErrGameNotActive, "room is %s", r.Status)
+	}
+	seat := r.seatOf(pid)
+	if seat == "" {
+		return rejectMove(ErrNotPlayer, "")
+	}
+	state := r.GameState()
+	if state == nil {
+		return rejectMove(ErrIllegalMove, "this server doesn't host %q", r.GameType)
+	}
+
+	// A move after the flag fell loses on time instead of being played
+	now := time.Now()
+	if state.ToMove() == seat && r.Clock.Flagged(seat, now) {
+		return r.flag(state, now)
+	}
+
+	if err := state.Check(seat, move); err != nil {
+		if errors.Is(err, ErrNotYourTurn) || err == ErrIllegalMove {
+			return rejectMove(err, "")
+		}
+		return rejectMove(ErrIllegalMove, "%v", err)
+	}
+	// The clock that was running is the side to move's, whoever acted
+	mover := state.ToMove()
+	next := state.Apply(seat, move)
+	if next.Outcome().Over {
+		r.Clock.Stop(mover, now)
+	} else {
+		r.Clock.Punch(mover, now)
+	}
+	return r.SetState(next)
+}
+
+// checkFlag ends the game on time if the side to move has run out.
+// Anything else leaves the room as it was.
+func (r *Room) checkFlag() error {
+	now := time.Now()
+	state := r.GameState()
+	if r.Status != "playing" || state == nil || !r.Clock.Flagged(state.ToMove(), now) {
+		return errUnchanged
+	}
+	return r.flag(state, now)
+}
+
+// flag ends the game because the side to move ran out of time. Games that
+// have their own rules for it decide the result; in the rest they lose.
+func (r *Room) flag(state game.State, now time.Time) error {
+	loser := state.ToMove()
+	r.Clock.Stop(loser, now)
+	if t, ok := state.(game.TimeoutRules); ok {
+		return r.SetState(t.Timeout(loser))
+	}
+	r.finish(game.Outcome{Over: true, Winner: game.Other(loser), Reason: game.ReasonTimeout})
+	r.UpdatedAt = now.Unix()
+	return nil
+}
+
+// restart starts a rematch for pid once the game is over, with nextTurn to
+// move. Like a move, it must be made on the room's latest version, so a
+// second request for the same rematch can't wipe out the first.
+func (r *Room) restart(pid, nextTurn string, seen int64) error {
+	if err := r.checkVersion(seen); err != nil {
+		return err
+	}
+	if r.Status != "finished" {
+		return rejectMove(ErrGameNotActive, "room is %s", r.Status)
+	}
+	if r.seatOf(pid) == "" {
+		return rejectMove(ErrNotPlayer, "")
+	}
+	state := r.GameState()
+	if state == nil {
+		return errUnchanged
+	}
+	r.Winner = ""
+	r.Reason = ""
+	r.Status = "playing"
+	r.StartedAt = time.Now().Unix()
+	if err := r.SetState(state.Rematch(nextTurn)); err != nil {
+		return err
+	}
+	r.Clock.Reset()
+	if r.PlayerO != "" {
+		r.Clock.Start(time.Now())
+	}
+	return nil
+}
+
+// Record returns the room's game written out by its game's Recorder, if
+// it has one
+func (r Room) Record() (string, bool) {
+	rec, ok := r.GameState().(game.Recorder)
+	if !ok {
+		return "", false
+	}
+	return rec.Record(r.Info()), true
+}
+
+// worthArchiving reports whether the room holds a game whose record
+// should outlive the room
+func (r Room) worthArchiving() bool {
+	rec, ok := r.GameState().(game.Recorder)
+	return ok && rec.MoveCount() > 0
+}
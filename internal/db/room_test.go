@@ -0,0 +1,226 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aminshahid573/termplay/internal/game"
+	_ "github.com/aminshahid573/termplay/internal/games"
+)
+
+func TestVersionOnlyMovesWithThePlay(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(s Store) error
+		bumps bool
+	}{
+		{"spectator joins", func(s Store) error { return s.JoinRoom("ABCD", "watcher", "Wendy") }, false},
+		{"spectator leaves", func(s Store) error {
+			if err := s.JoinRoom("ABCD", "watcher", "Wendy"); err != nil {
+				return err
+			}
+			return s.LeaveRoom("ABCD", "watcher", false)
+		}, false},
+		{"flag checked on an untimed game", func(s Store) error { return s.CheckFlag("ABCD") }, false},
+		{"move", func(s Store) error {
+			r, err := s.GetRoom("ABCD")
+			if err != nil {
+				return err
+			}
+			mover := r.PlayerX
+			if r.Turn != game.X {
+				mover = r.PlayerO
+			}
+			return s.SubmitMove("ABCD", mover, "4", r.Version)
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewMemoryStore()
+			if err := s.CreateRoom("ABCD", "host", "Hana", RoomOptions{GameType: "tictactoe"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.JoinRoom("ABCD", "guest", "Gus"); err != nil {
+				t.Fatal(err)
+			}
+			before, err := s.GetRoom("ABCD")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.write(s); err != nil {
+				t.Fatal(err)
+			}
+			after, err := s.GetRoom("ABCD")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bumped := after.Version != before.Version; bumped != tt.bumps {
+				t.Errorf("version went from %d to %d, want bumped = %v", before.Version, after.Version, tt.bumps)
+			}
+		})
+	}
+}
+
+func TestMoveSurvivesSpectator(t *testing.T) {
+	s := NewMemoryStore()
+	if err := s.CreateRoom("ABCD", "host", "Hana", RoomOptions{GameType: "tictactoe"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.JoinRoom("ABCD", "guest", "Gus"); err != nil {
+		t.Fatal(err)
+	}
+	r, err := s.GetRoom("ABCD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The host moves on the snapshot they saw before the spectator came
+	if err := s.JoinRoom("ABCD", "watcher", "Wendy"); err != nil {
+		t.Fatal(err)
+	}
+	mover, other := r.PlayerX, r.PlayerO
+	if r.Turn != game.X {
+		mover, other = other, mover
+	}
+	if err := s.SubmitMove("ABCD", mover, "4", r.Version); err != nil {
+		t.Fatalf("move after a spectator joined: %v", err)
+	}
+	if err := s.SubmitMove("ABCD", other, "0", r.Version); !errors.Is(err, ErrStaleRoom) {
+		t.Errorf("move on a snapshot from before the last move: got %v, want %v", err, ErrStaleRoom)
+	}
+}
+
+func TestRestart(t *testing.T) {
+	// finished plays out a tic-tac-toe game that the side to move wins
+	finished := func(t *testing.T, s Store) *Room {
+		t.Helper()
+		for _, move := range []string{"0", "3", "1", "4", "2"} {
+			r, err := s.GetRoom("ABCD")
+			if err != nil {
+				t.Fatal(err)
+			}
+			mover := r.PlayerX
+			if r.Turn != game.X {
+				mover = r.PlayerO
+			}
+			if err := s.SubmitMove("ABCD", mover, move, r.Version); err != nil {
+				t.Fatal(err)
+			}
+		}
+		r, err := s.GetRoom("ABCD")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Status != "finished" {
+			t.Fatalf("game is %s after a won line", r.Status)
+		}
+		return r
+	}
+	tests := []struct {
+		name    string
+		restart func(t *testing.T, s Store) error
+		want    error
+	}{
+		{"by the host", func(t *testing.T, s Store) error {
+			r := finished(t, s)
+			return s.RestartGame("ABCD", "host", game.O, r.Version)
+		}, nil},
+		{"by the guest", func(t *testing.T, s Store) error {
+			r := finished(t, s)
+			return s.RestartGame("ABCD", "guest", game.X, r.Version)
+		}, nil},
+		{"by a spectator", func(t *testing.T, s Store) error {
+			if err := s.JoinRoom("ABCD", "watcher", "Wendy"); err != nil {
+				return err
+			}
+			r := finished(t, s)
+			return s.RestartGame("ABCD", "watcher", game.X, r.Version)
+		}, ErrNotPlayer},
+		{"mid-game", func(t *testing.T, s Store) error {
+			r, err := s.GetRoom("ABCD")
+			if err != nil {
+				return err
+			}
+			return s.RestartGame("ABCD", "host", game.X, r.Version)
+		}, ErrGameNotActive},
+		{"both players at once", func(t *testing.T, s Store) error {
+			r := finished(t, s)
+			if err := s.RestartGame("ABCD", "host", game.X, r.Version); err != nil {
+				return err
+			}
+			return s.RestartGame("ABCD", "guest", game.O, r.Version)
+		}, ErrStaleRoom},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewMemoryStore()
+			if err := s.CreateRoom("ABCD", "host", "Hana", RoomOptions{GameType: "tictactoe"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.JoinRoom("ABCD", "guest", "Gus"); err != nil {
+				t.Fatal(err)
+			}
+			err := tt.restart(t, s)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("RestartGame = %v, want %v", err, tt.want)
+			}
+			r, err := s.GetRoom("ABCD")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == nil && (r.Status != "playing" || r.Winner != "") {
+				t.Errorf("after the rematch the room is %s, won by %q", r.Status, r.Winner)
+			}
+		})
+	}
+}
+
+func TestForHidesFleets(t *testing.T) {
+	s := NewMemoryStore()
+	if err := s.CreateRoom("ABCD", "host", "Hana", RoomOptions{GameType: "battleship"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.JoinRoom("ABCD", "guest", "Gus"); err != nil {
+		t.Fatal(err)
+	}
+	for _, pid := range []string{"host", "guest"} {
+		r, err := s.GetRoom("ABCD")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SubmitMove("ABCD", pid, "fleet A1H B1H C1H D1H E1H", r.Version); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r, err := s.GetRoom("ABCD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, pid     string
+		shown, hidden []string
+	}{
+		{"host", "host", []string{`"shipsX"`}, []string{`"shipsO"`}},
+		{"guest", "guest", []string{`"shipsO"`}, []string{`"shipsX"`}},
+		{"spectator", "watcher", nil, []string{`"shipsX"`, `"shipsO"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := string(r.For(tt.pid).State)
+			for _, key := range tt.shown {
+				if !strings.Contains(state, key) {
+					t.Errorf("%s can't see %s: %s", tt.name, key, state)
+				}
+			}
+			for _, key := range tt.hidden {
+				if strings.Contains(state, key) {
+					t.Errorf("%s can see %s: %s", tt.name, key, state)
+				}
+			}
+			if !strings.Contains(state, `"placed"`) {
+				t.Errorf("%s can't see who has placed: %s", tt.name, state)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// bestScore is what pid keeps on a board after scoring score: the better of
+// it and old, their best so far if they had one, under the name they go by
+// now. changed reports whether there is anything to write.
+func bestScore(old game.Score, had bool, pid, name string, score int, replay string) (best game.Score, changed bool) {
+	if had && old.Score >= score {
+		if old.Name == name {
+			return old, false
+		}
+		old.Name = name
+		return old, true
+	}
+	return game.Score{ID: pid, Name: name, Score: score, At: time.Now().Unix(), Replay: replay}, true
+}
+
+// rankScores sorts a whole board best first and cuts it to the top n, with
+// pid's own place on it
+func rankScores(scores []game.Score, pid string, n int) game.Leaderboard {
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].At < scores[j].At
+	})
+
+	var lb game.Leaderboard
+	for i, s := range scores {
+		if s.ID == pid {
+			lb.Mine, lb.Rank = s, i+1
+			break
+		}
+	}
+	lb.Top = scores[:min(n, len(scores))]
+	return lb
+}
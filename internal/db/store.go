@@ -0,0 +1,124 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aminshahid573/termplay/internal/config"
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+var (
+	ErrRoomNotFound = errors.New("room does not exist")
+	ErrRoomTaken    = errors.New("room code taken")
+	ErrNoRecord     = errors.New("game has no record to export")
+
+	// Move rejections, always wrapped in a *MoveError
+	ErrNotPlayer     = errors.New("you are not playing in this room")
+	ErrNotYourTurn   = game.ErrNotYourTurn
+	ErrGameNotActive = errors.New("game is not in progress")
+	ErrIllegalMove   = game.ErrIllegalMove
+	ErrStaleRoom     = errors.New("room changed since you last saw it")
+
+	// errUnchanged lets a mutation bail out without writing, and without
+	// the caller seeing an error
+	errUnchanged = errors.New("room unchanged")
+)
+
+// MoveError is returned when the server rejects a move. Err is one of the
+// move sentinels above, so callers can check it with errors.Is.
+type MoveError struct {
+	Err    error
+	Detail string
+}
+
+func (e *MoveError) Error() string {
+	if e.Detail == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + ": " + e.Detail
+}
+
+func (e *MoveError) Unwrap() error {
+	return e.Err
+}
+
+func rejectMove(err error, format string, args ...interface{}) error {
+	return &MoveError{Err: err, Detail: fmt.Sprintf(format, args...)}
+}
+
+// RoomOptions are the host's choices when creating a room
+type RoomOptions struct {
+	Public   bool
+	GameType string
+	// Variant is passed to the game's Rules.New, e.g. a chess FEN
+	Variant any
+	// TimeControl sets up the clocks; the zero value is untimed
+	TimeControl game.TimeControl
+}
+
+// Store is everything the UI needs from a room backend. Firebase is the
+// default; bolt keeps rooms in a local file for self-hosting, and the
+// in-memory store is handy for local runs and as a fake.
+type Store interface {
+	CreateRoom(code, pid, name string, opts RoomOptions) error
+	GetRoom(code string) (*Room, error)
+	JoinRoom(code, pid, name string) error
+	LeaveRoom(code, pid string, isHost bool) error
+	// SubmitMove plays move, in the notation of the room's game, for pid.
+	// The move is checked against the stored game and pid's seat.
+	// version is the Room.Version the move was made against; if the room
+	// has moved on since, the move fails with ErrStaleRoom.
+	// If pid's clock has already run out, the game ends on time instead.
+	SubmitMove(code, pid, move string, version int64) error
+	// CheckFlag ends the game in code on time if the side to move has run
+	// out. The server's clock decides, so any viewer may call it.
+	CheckFlag(code string) error
+	// RestartGame starts a rematch for pid with nextTurn, a seat, to move.
+	// The game must be over, and like a move, version must be the room's
+	// latest or it fails with ErrStaleRoom.
+	RestartGame(code, pid, nextTurn string, version int64) error
+	GetPublicRooms() ([]Room, error)
+	// GetRecord returns the game in room code in its game's record format,
+	// such as PGN for chess. Once a room is deleted, the record of its last
+	// game is still served from the archive.
+	GetRecord(code string) (string, error)
+	// CleanZombies removes rooms that haven't been updated in 1 hour
+	CleanZombies()
+
+	// SubmitScore records score for pid, called name, on a leaderboard
+	// such as "snake-hard", if it beats their best there. replay is the
+	// game's recording of the run, kept with the score.
+	SubmitScore(board, pid, name string, score int, replay string) error
+	// GetLeaderboard returns the top n scores on board, and pid's own
+	GetLeaderboard(board, pid string, n int) (game.Leaderboard, error)
+
+	// SaveItem keeps data that pid made, such as a level, under kind and
+	// name, replacing any item of that name. Names are checked with
+	// CheckItemName.
+	SaveItem(pid, kind, name, data string) error
+	// GetItems returns everything of kind that pid has saved, by name
+	GetItems(pid, kind string) (map[string]string, error)
+	DeleteItem(pid, kind, name string) error
+}
+
+// Watcher is implemented by backends that can push room changes instead of
+// being polled. fn gets the fresh room, or nil once the room is gone.
+// Calling stop ends the watch.
+type Watcher interface {
+	Watch(code string, fn func(r *Room)) (stop func())
+}
+
+// Open returns the backend selected by config.Storage
+func Open() (Store, error) {
+	switch config.Storage {
+	case "", "firebase":
+		return newFirebaseStore()
+	case "bolt":
+		return newBoltStore(config.BoltPath)
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown STORAGE backend %q", config.Storage)
+	}
+}
@@ -0,0 +1,137 @@
+package game
+
+import (
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CursorSquare is the background of the square under a board cursor
+var CursorSquare = lipgloss.Color("#FFD700")
+
+// SquareSize picks the size of one square of an n×n board so it fits the
+// terminal, keeping squares about twice as wide as they are high
+func SquareSize(n, termWidth, termHeight int) (sqW, sqH int) {
+	availW := termWidth - 8
+	availH := termHeight - 14 // Increased buffer for UI elements
+
+	if availW < n*2 || availH < n {
+		return 2, 1
+	}
+
+	cellUnit := min(availW/(n*2), availH/n)
+	cellUnit = min(max(cellUnit, 1), 3)
+	return cellUnit * 2, cellUnit
+}
+
+// Square draws one square of a checkered board
+func Square(text string, fg, bg lipgloss.Color, sqW, sqH int) string {
+	return lipgloss.NewStyle().
+		Background(bg).
+		Foreground(fg).
+		Bold(true).
+		Width(sqW).
+		Height(sqH).
+		Align(lipgloss.Center, lipgloss.Center).
+		Render(text)
+}
+
+// LabeledGrid frames a square board with files above and below and ranks
+// on both sides, top to bottom as they appear on screen. square draws the
+// square at screen row r, column c.
+func LabeledGrid(files, ranks []string, sqW, sqH int, square func(r, c int) string) string {
+	var rankLabels []string
+	var rankLabelsR []string
+	var gridRows []string
+
+	for r, rank := range ranks {
+		rankLabel := lipgloss.NewStyle().
+			Foreground(styles.ChessLabel).
+			Bold(true).
+			Width(2).
+			Height(sqH).
+			Align(lipgloss.Right, lipgloss.Center).
+			PaddingRight(1).
+			Render(rank)
+		rankLabels = append(rankLabels, rankLabel)
+
+		rankLabelR := lipgloss.NewStyle().
+			Foreground(styles.ChessLabel).
+			Bold(true).
+			Width(2).
+			Height(sqH).
+			Align(lipgloss.Left, lipgloss.Center).
+			PaddingLeft(1).
+			Render(rank)
+		rankLabelsR = append(rankLabelsR, rankLabelR)
+
+		var rowCells []string
+		for c := range files {
+			rowCells = append(rowCells, square(r, c))
+		}
+		gridRows = append(gridRows, lipgloss.JoinHorizontal(lipgloss.Top, rowCells...))
+	}
+
+	grid := lipgloss.JoinVertical(lipgloss.Left, gridRows...)
+
+	// Frame the grid
+	gridWithBorder := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(styles.ChessBorder).
+		Render(grid)
+
+	// Join ranks
+	leftRanks := lipgloss.JoinVertical(lipgloss.Top, rankLabels...)
+	rightRanks := lipgloss.JoinVertical(lipgloss.Top, rankLabelsR...)
+
+	// Add margin to ranks to account for top border
+	leftRanks = lipgloss.NewStyle().MarginTop(1).Render(leftRanks)
+	rightRanks = lipgloss.NewStyle().MarginTop(1).Render(rightRanks)
+
+	boardArea := lipgloss.JoinHorizontal(lipgloss.Top, leftRanks, gridWithBorder, rightRanks)
+
+	// File labels
+	var fileLabels []string
+	for _, f := range files {
+		fl := lipgloss.NewStyle().
+			Foreground(styles.ChessLabel).
+			Bold(true).
+			Width(sqW).
+			Align(lipgloss.Center).
+			Render(f)
+		fileLabels = append(fileLabels, fl)
+	}
+
+	// PaddingLeft = RankWidth (3) + Border (1) = 4
+	fileLabelRow := lipgloss.NewStyle().
+		PaddingLeft(4).
+		Render(lipgloss.JoinHorizontal(lipgloss.Top, fileLabels...))
+
+	return lipgloss.JoinVertical(lipgloss.Center,
+		fileLabelRow,
+		"",
+		boardArea,
+		"",
+		fileLabelRow,
+	)
+}
+
+// BoardFrame puts the block border used around the 8×8 games on content
+func BoardFrame(content string) string {
+	blockBorder := lipgloss.Border{
+		Top:         "▄",
+		Bottom:      "▀",
+		Left:        "▐",
+		Right:       "▌",
+		TopLeft:     "▗",
+		TopRight:    "▖",
+		BottomLeft:  "▝",
+		BottomRight: "▘",
+	}
+
+	return lipgloss.NewStyle().
+		Border(blockBorder).
+		BorderForeground(styles.ChessBorder).
+		Padding(1, 2).
+		Render(content)
+}
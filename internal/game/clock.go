@@ -0,0 +1,135 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReasonTimeout ends a game where a player ran out of time
+const ReasonTimeout = "timeout"
+
+// TimeControl is the starting time per player plus the increment added
+// after each of their moves. The zero value means an untimed game.
+type TimeControl struct {
+	Base      time.Duration
+	Increment time.Duration
+}
+
+// TimeControlPresets are the named time controls offered when creating a room
+var TimeControlPresets = []struct {
+	Name string
+	TC   TimeControl
+}{
+	{"Untimed", TimeControl{}},
+	{"Bullet 1+0", TimeControl{Base: time.Minute}},
+	{"Blitz 3+2", TimeControl{Base: 3 * time.Minute, Increment: 2 * time.Second}},
+	{"Rapid 10+5", TimeControl{Base: 10 * time.Minute, Increment: 5 * time.Second}},
+}
+
+// ParseTimeControl reads the usual "minutes+seconds" notation, e.g. "15+10"
+// for 15 minutes with a 10 second increment. The increment may be left out.
+func ParseTimeControl(s string) (TimeControl, error) {
+	base, inc, _ := strings.Cut(strings.TrimSpace(s), "+")
+	mins, err := strconv.ParseFloat(strings.TrimSpace(base), 64)
+	if err != nil || mins <= 0 || mins > 180 {
+		return TimeControl{}, fmt.Errorf("base time must be more than 0 and at most 180 minutes, got %q", base)
+	}
+	tc := TimeControl{Base: time.Duration(mins * float64(time.Minute))}
+	if inc != "" {
+		secs, err := strconv.Atoi(strings.TrimSpace(inc))
+		if err != nil || secs < 0 || secs > 180 {
+			return TimeControl{}, fmt.Errorf("increment must be 0-180 seconds, got %q", inc)
+		}
+		tc.Increment = time.Duration(secs) * time.Second
+	}
+	return tc, nil
+}
+
+func (tc TimeControl) String() string {
+	if tc.Base == 0 {
+		return "Untimed"
+	}
+	return fmt.Sprintf("%s+%d", strconv.FormatFloat(tc.Base.Minutes(), 'f', -1, 64), int(tc.Increment.Seconds()))
+}
+
+// Clock holds both seats' remaining time in milliseconds. Only the side
+// to move's clock runs, counted from RunningSince.
+type Clock struct {
+	XMs          int64 `json:"xMs"`
+	OMs          int64 `json:"oMs"`
+	BaseMs       int64 `json:"baseMs"` // 0 = untimed
+	IncrementMs  int64 `json:"incrementMs"`
+	RunningSince int64 `json:"runningSince"` // Unix ms, 0 while stopped
+}
+
+// NewClock returns a stopped clock set to tc
+func NewClock(tc TimeControl) Clock {
+	base := tc.Base.Milliseconds()
+	return Clock{XMs: base, OMs: base, BaseMs: base, IncrementMs: tc.Increment.Milliseconds()}
+}
+
+// Timed reports whether the game has a clock at all
+func (c Clock) Timed() bool {
+	return c.BaseMs > 0
+}
+
+// Reset puts both players back on the base time, stopped
+func (c *Clock) Reset() {
+	c.XMs, c.OMs, c.RunningSince = c.BaseMs, c.BaseMs, 0
+}
+
+// Start runs the clock of the side to move from now
+func (c *Clock) Start(now time.Time) {
+	if c.Timed() && c.RunningSince == 0 {
+		c.RunningSince = now.UnixMilli()
+	}
+}
+
+// Stop charges the time used so far to side and stops the clock
+func (c *Clock) Stop(side string, now time.Time) {
+	if c.RunningSince != 0 {
+		left := c.side(side)
+		*left -= now.UnixMilli() - c.RunningSince
+		if *left < 0 {
+			*left = 0
+		}
+		c.RunningSince = 0
+	}
+}
+
+// Remaining returns side's time left as of now, counting a running clock
+func (c Clock) Remaining(side, turn string, now time.Time) time.Duration {
+	ms := *c.side(side)
+	if side == turn && c.RunningSince != 0 {
+		ms -= now.UnixMilli() - c.RunningSince
+	}
+	if ms < 0 {
+		ms = 0
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// Flagged reports whether turn, the side to move, has run out of time
+func (c Clock) Flagged(turn string, now time.Time) bool {
+	return c.Timed() && c.Remaining(turn, turn, now) <= 0
+}
+
+// Punch ends side's move: their time is charged, the increment added and
+// the opponent's clock started.
+func (c *Clock) Punch(side string, now time.Time) {
+	if !c.Timed() {
+		return
+	}
+	c.Stop(side, now)
+	*c.side(side) += c.IncrementMs
+	c.Start(now)
+}
+
+func (c *Clock) side(side string) *int64 {
+	if side == X {
+		return &c.XMs
+	}
+	return &c.OMs
+}
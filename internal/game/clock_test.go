@@ -0,0 +1,98 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeControl(t *testing.T) {
+	tests := []struct {
+		text string
+		want TimeControl
+		name string // What String writes it back as
+	}{
+		{"5", TimeControl{Base: 5 * time.Minute}, "5+0"},
+		{"15+10", TimeControl{Base: 15 * time.Minute, Increment: 10 * time.Second}, "15+10"},
+		{" 3 + 2 ", TimeControl{Base: 3 * time.Minute, Increment: 2 * time.Second}, "3+2"},
+		{"0.5+0", TimeControl{Base: 30 * time.Second}, "0.5+0"},
+		{"180+180", TimeControl{Base: 180 * time.Minute, Increment: 180 * time.Second}, "180+180"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			tc, err := ParseTimeControl(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc != tt.want {
+				t.Errorf("ParseTimeControl = %+v, want %+v", tc, tt.want)
+			}
+			if tc.String() != tt.name {
+				t.Errorf("String = %q, want %q", tc.String(), tt.name)
+			}
+		})
+	}
+}
+
+func TestParseTimeControlRejects(t *testing.T) {
+	for _, text := range []string{"", "0", "-1", "181", "blitz", "5+-1", "5+181", "5+2.5"} {
+		if tc, err := ParseTimeControl(text); err == nil {
+			t.Errorf("ParseTimeControl(%q) = %+v, want an error", text, tc)
+		}
+	}
+}
+
+func TestClock(t *testing.T) {
+	start := time.UnixMilli(1_000_000)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	tests := []struct {
+		name        string
+		tc          TimeControl
+		play        func(c *Clock)
+		turn        string
+		now         time.Duration
+		wantX       time.Duration
+		wantO       time.Duration
+		wantFlagged bool
+	}{
+		{"stopped clock doesn't run", TimeControl{Base: time.Minute}, func(c *Clock) {}, X, time.Hour, time.Minute, time.Minute, false},
+		{"side to move runs down", TimeControl{Base: time.Minute}, func(c *Clock) { c.Start(start) }, X, 20 * time.Second, 40 * time.Second, time.Minute, false},
+		{"punch charges the mover and adds the increment", TimeControl{Base: time.Minute, Increment: 5 * time.Second}, func(c *Clock) {
+			c.Start(start)
+			c.Punch(X, at(20*time.Second))
+		}, O, 30 * time.Second, 45 * time.Second, 50 * time.Second, false},
+		{"stop keeps what is left", TimeControl{Base: time.Minute, Increment: 5 * time.Second}, func(c *Clock) {
+			c.Start(start)
+			c.Stop(X, at(20*time.Second))
+		}, X, time.Hour, 40 * time.Second, time.Minute, false},
+		{"a clock can't go below zero", TimeControl{Base: time.Minute}, func(c *Clock) {
+			c.Start(start)
+			c.Stop(X, at(2*time.Minute))
+		}, X, 0, 0, time.Minute, true},
+		{"flag falls as time runs out", TimeControl{Base: time.Minute}, func(c *Clock) { c.Start(start) }, X, time.Minute, 0, time.Minute, true},
+		{"reset puts both back on the base time", TimeControl{Base: time.Minute}, func(c *Clock) {
+			c.Start(start)
+			c.Punch(X, at(20*time.Second))
+			c.Reset()
+		}, O, time.Hour, time.Minute, time.Minute, false},
+		{"untimed never runs or falls", TimeControl{}, func(c *Clock) {
+			c.Start(start)
+			c.Punch(X, at(time.Minute))
+		}, O, time.Hour, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClock(tt.tc)
+			tt.play(&c)
+			now := at(tt.now)
+			if x := c.Remaining(X, tt.turn, now); x != tt.wantX {
+				t.Errorf("X has %v left, want %v", x, tt.wantX)
+			}
+			if o := c.Remaining(O, tt.turn, now); o != tt.wantO {
+				t.Errorf("O has %v left, want %v", o, tt.wantO)
+			}
+			if flagged := c.Flagged(tt.turn, now); flagged != tt.wantFlagged {
+				t.Errorf("Flagged = %v, want %v", flagged, tt.wantFlagged)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+// Package game is the registry of games TermPlay can host, and the
+// interfaces a game implements to plug into rooms, the menus and the UI.
+//
+// A two-player game provides Rules, whose State is stored in the room and
+// checked on the server, and a Screen that draws it and turns keys into
+// moves. A single-player game, like Snake, provides an Arcade instead. A
+// real-time room game, like the snake arena, provides a Live game that the
+// server runs for everyone in the room.
+package game
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/ai"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Seats. The host sits at X, the guest at O.
+const (
+	X = "X"
+	O = "O"
+)
+
+// Other returns the seat opposite seat
+func Other(seat string) string {
+	if seat == X {
+		return O
+	}
+	return X
+}
+
+// Errors a State returns from Check. Anything else is taken as an illegal
+// move, with the error as the reason.
+var (
+	ErrNotYourTurn = errors.New("not your turn")
+	ErrIllegalMove = errors.New("illegal move")
+)
+
+// Game is one entry in the game menu
+type Game struct {
+	ID   string // Stored as the room's GameType
+	Name string // Shown in the menus
+	// Seats names the X and O seats in this game, e.g. White and Black
+	Seats [2]string
+
+	// Rules, NewScreen and NewSetup make a room game. NewSetup may be nil
+	// when a game has no settings.
+	Rules     Rules
+	NewScreen func() Screen
+	NewSetup  func() Setup
+
+	// Engine plays the other seat in solo games; nil if there is none.
+	// NewSoloSetup picks the variant a solo game is played on, and may be
+	// nil when there is only the default one.
+	Engine       Engine
+	NewSoloSetup func() Setup
+	// AlternateFirst makes solo rematches swap which seat opens
+	AlternateFirst bool
+
+	// NewArcade starts a single-player game instead of a room one, for
+	// player
+	NewArcade func(width, height int, player Player) (Arcade, tea.Cmd)
+
+	// NewLive starts a real-time room game instead of a turn-based one
+	NewLive func() Live
+}
+
+// SeatName returns what seat is called in g
+func (g *Game) SeatName(seat string) string {
+	if seat == O {
+		return g.Seats[1]
+	}
+	return g.Seats[0]
+}
+
+// Rules start games and read them back from storage
+type Rules interface {
+	// New starts a game with X to move. variant is the Settings.Variant
+	// picked in the game's Setup, or nil for the default game.
+	New(variant any) (State, error)
+	// Decode reads a State stored with encoding/json
+	Decode(data []byte) (State, error)
+}
+
+// State is one position of a game. States are values: Apply and Rematch
+// return a new State and leave the old one untouched. They are stored with
+// encoding/json, so everything that matters must be in exported fields.
+type State interface {
+	// ToMove is the seat whose turn it is
+	ToMove() string
+	// Check reports whether seat may play move now: nil, ErrNotYourTurn,
+	// or an error saying why the move is illegal
+	Check(seat, move string) error
+	// Apply plays a move that passed Check
+	Apply(seat, move string) State
+	// Outcome reports whether the game is over and who won
+	Outcome() Outcome
+	// Rematch starts a new game with the same settings. first opens, unless
+	// the settings fix who moves first.
+	Rematch(first string) State
+}
+
+// Outcome is how a game ended
+type Outcome struct {
+	Over   bool
+	Winner string // X, O, or "" for a draw
+	Reason string // Optional, e.g. "checkmate"
+}
+
+// TimeoutRules is implemented by states that decide for themselves what
+// running out of time means. Others lose to the opponent outright.
+type TimeoutRules interface {
+	Timeout(loser string) State
+}
+
+// Recorder is implemented by states that can be written out as a standard
+// game record, like PGN for chess. Rooms keep the record of their last game
+// for a while after they close.
+type Recorder interface {
+	// Record writes the game out, with the players and room from info
+	Record(info Info) string
+	// MoveCount is how many moves have been played
+	MoveCount() int
+}
+
+// Secret is implemented by states that hold something a player mustn't
+// see, like where the other player's ships are. Sessions only ever get the
+// state as Redact returns it for their seat, and spectators ("") see
+// neither side's secrets. Check and Apply still run on the full state.
+type Secret interface {
+	Redact(seat string) State
+}
+
+// Engine is a computer opponent for solo games
+type Engine interface {
+	Levels() []ai.Level
+	// Move picks a move for the side to move in s
+	Move(ctx context.Context, s State, lvl ai.Level) (move string, ok bool)
+}
+
+// Settings are a game's part of the room settings
+type Settings struct {
+	Variant     any // Passed to Rules.New
+	TimeControl TimeControl
+}
+
+// Setup is a game's part of the room settings screen
+type Setup interface {
+	Update(msg tea.KeyMsg) (Setup, tea.Cmd)
+	View() string
+	Help() string
+	// Settings returns the choices made, or an error to show the host
+	Settings() (Settings, error)
+}
+
+// View is everything a Screen needs to draw a frame
+type View struct {
+	Game  *Game
+	State State
+	Info  Info
+	Seat  string // X or O for players, "" for spectators
+	// Width and Height are the terminal size
+	Width, Height int
+}
+
+// Info is the room around a game
+type Info struct {
+	Code         string // "" for solo games
+	NameX, NameO string
+	WinsX, WinsO int
+	Status       string // "waiting", "playing" or "finished"
+	Winner       string // X, O, or "" for a draw
+	Reason       string
+	Clock        Clock
+	StartedAt    int64 // Unix seconds
+	Thinking     bool  // The computer is choosing its move
+}
+
+// Name returns the player in seat
+func (i Info) Name(seat string) string {
+	if seat == O {
+		return i.NameO
+	}
+	return i.NameX
+}
+
+// MyTurn reports whether the viewer may move now
+func (v View) MyTurn() bool {
+	return v.Seat != "" && v.Info.Status == "playing" && v.State.ToMove() == v.Seat
+}
+
+// Screen draws a game in a room and turns keys into moves. Each session
+// has its own, so it can hold cursors and selections.
+type Screen interface {
+	// Sync is called with every new snapshot of the room
+	Sync(v View) Screen
+	// Update handles a key while the game is on
+	Update(v View, msg tea.KeyMsg) (Screen, Action)
+	// Render draws the whole game: title, players, board and status
+	Render(v View) string
+	// Help is the key help shown under the game
+	Help(v View) string
+}
+
+// Modal is implemented by screens that sometimes need a popup, like the
+// chess promotion picker. While Modal returns something it is shown in a
+// popup and every key goes to Update.
+type Modal interface {
+	Modal(v View) string
+}
+
+// Action is what a Screen wants done after a key
+type Action struct {
+	// Move is sent to the server, or applied locally in solo games
+	Move string
+	// Share shows Text in a popup and copies it to the clipboard
+	Share *Share
+	// Unhandled leaves the key to the UI, e.g. esc to leave the room
+	Unhandled bool
+}
+
+// Share is some text worth copying, such as a game record
+type Share struct {
+	Title string
+	Text  string
+}
+
+// Arcade is a single-player game with its own loop
+type Arcade interface {
+	Update(msg tea.Msg) (Arcade, tea.Cmd)
+	View(width, height int) string
+	// Done reports that the player wants to go back to the game menu
+	Done() bool
+}
+
+// Live is a real-time room game. The server runs one per room, shared by
+// everyone in it and stepped at a fixed tick: keys come in through Input,
+// and after every Step each session is sent a Frame to draw.
+type Live interface {
+	// Tick is the time between steps
+	Tick() time.Duration
+	Step()
+	// Input handles a key from pid, who is called name
+	Input(pid, name, key string)
+	// Leave takes pid out of the game
+	Leave(pid string)
+	// Frame is the game as it stands. It must not share memory with the
+	// game, since sessions draw it while the next step runs.
+	Frame() Frame
+}
+
+// Frame is a snapshot of a Live game
+type Frame interface {
+	// Render draws the game as pid sees it
+	Render(pid string, width, height int) string
+	// Help is the key help shown to pid
+	Help(pid string) string
+}
+
+var registry []*Game
+
+// Register adds games to the menu, in order
+func Register(games ...*Game) {
+	registry = append(registry, games...)
+}
+
+// All lists the registered games in menu order
+func All() []*Game {
+	return registry
+}
+
+// Lookup returns the game with id, or nil
+func Lookup(id string) *Game {
+	for _, g := range registry {
+		if g.ID == id {
+			return g
+		}
+	}
+	return nil
+}
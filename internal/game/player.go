@@ -0,0 +1,46 @@
+package game
+
+// Score is one player's best on a leaderboard
+type Score struct {
+	ID    string `json:"id"` // Player ID, as for rooms
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+	At    int64  `json:"at"` // Unix seconds it was set; earlier ranks first on a tie
+	// Replay is the game's own recording of the run, if it keeps one
+	Replay string `json:"replay,omitempty"`
+}
+
+// Leaderboard is the top of a board, and where the player stands on it
+type Leaderboard struct {
+	Top  []Score
+	Mine Score // Zero until they have a score
+	Rank int   // Mine's place from 1; 0 until they have a score
+}
+
+// Leaderboards keep an arcade player's best scores across sessions, on
+// named boards such as "snake-hard". The calls go to the store, so make
+// them from a tea.Cmd.
+type Leaderboards interface {
+	// Submit records score, and the replay of its run, on board if it
+	// beats the player's best
+	Submit(board string, score int, replay string) error
+	// Leaderboard returns the top n of board and the player's place on it
+	Leaderboard(board string, n int) (Leaderboard, error)
+}
+
+// Library keeps things an arcade player makes, such as their own levels,
+// by kind and under names of their choosing. Like Leaderboards, its calls
+// go to the store.
+type Library interface {
+	Save(kind, name, data string) error
+	// Load returns everything of kind the player has saved, by name
+	Load(kind string) (map[string]string, error)
+	Delete(kind, name string) error
+}
+
+// Player is what the server keeps for the player of an arcade game. Both
+// are nil when there is no store.
+type Player struct {
+	Scores  Leaderboards
+	Library Library
+}
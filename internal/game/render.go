@@ -0,0 +1,92 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Scoreboard is the players line above a board: names, seats, wins and,
+// in timed games, the clocks
+func Scoreboard(v View) string {
+	side := func(seat string) string {
+		s := fmt.Sprintf("%s (%s, Wins: %d)", v.Info.Name(seat), v.Game.SeatName(seat), v.Info.wins(seat))
+		if v.Info.Clock.Timed() {
+			s += " " + renderClock(v, seat)
+		}
+		return s
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Center, side(X), "  VS  ", side(O))
+}
+
+func (i Info) wins(seat string) int {
+	if seat == O {
+		return i.WinsO
+	}
+	return i.WinsX
+}
+
+// StatusLine is the usual line under a board. While the game is on it
+// says whose turn it is, with turn as the detail, e.g. "X".
+func StatusLine(v View, turn string) string {
+	switch v.Info.Status {
+	case "waiting":
+		return "Opponent disconnected. Waiting..."
+	case "finished":
+		if v.Info.Winner == "" {
+			return "DRAW"
+		}
+		if v.Info.Reason == ReasonTimeout {
+			return "TIME OUT! " + strings.ToUpper(v.Game.SeatName(v.Info.Winner)) + " WINS!"
+		}
+		return strings.ToUpper(v.Game.SeatName(v.Info.Winner)) + " WINS!"
+	}
+	status := "Turn: " + turn
+	switch {
+	case v.Seat == "":
+		status = "[SPECTATING] " + status
+	case v.Info.Thinking:
+		status += " (thinking...)"
+	}
+	return status
+}
+
+// renderClock shows seat's remaining time, lit up while it is running
+func renderClock(v View, seat string) string {
+	turn := v.State.ToMove()
+	left := v.Info.Clock.Remaining(seat, turn, time.Now())
+	style := styles.Subtle.Padding(0, 1)
+	if v.Info.Status == "playing" && v.Info.Clock.RunningSince != 0 && turn == seat {
+		style = styles.ItemFocused
+	}
+	if left < 10*time.Second {
+		style = style.Foreground(styles.ChessCapture)
+	}
+	return style.Render(formatClock(left))
+}
+
+// formatClock renders h:mm:ss, m:ss, or s.t in the last ten seconds
+func formatClock(d time.Duration) string {
+	if d < 10*time.Second {
+		return fmt.Sprintf("%.1f", d.Seconds())
+	}
+	d = d.Truncate(time.Second)
+	h, mnt, sec := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, mnt, sec)
+	}
+	return fmt.Sprintf("%d:%02d", mnt, sec)
+}
+
+// RenderPreset draws one of a row of choices, such as board sizes or time
+// controls in a game's Setup
+func RenderPreset(name string, selected bool) string {
+	if selected {
+		return styles.ItemFocused.Render(name)
+	}
+	return styles.ItemBlurred.Render(name)
+}
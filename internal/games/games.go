@@ -0,0 +1,31 @@
+// Package games registers every game TermPlay hosts, in menu order. Import
+// it for its side effects wherever the registry is needed.
+package games
+
+import (
+	"github.com/aminshahid573/termplay/internal/battleship"
+	"github.com/aminshahid573/termplay/internal/checkers"
+	"github.com/aminshahid573/termplay/internal/chess"
+	"github.com/aminshahid573/termplay/internal/connectfour"
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/goban"
+	"github.com/aminshahid573/termplay/internal/othello"
+	"github.com/aminshahid573/termplay/internal/snake"
+	"github.com/aminshahid573/termplay/internal/tictactoe"
+	"github.com/aminshahid573/termplay/internal/ultimate"
+)
+
+func init() {
+	game.Register(
+		tictactoe.Game,
+		chess.Game,
+		ultimate.Game,
+		connectfour.Game,
+		checkers.Game,
+		othello.Game,
+		goban.Game,
+		battleship.Game,
+		snake.Game,
+		snake.Arena,
+	)
+}
@@ -0,0 +1,172 @@
+package goban
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Game is Go. The host (X) plays Black and the guest (O) White. Moves are
+// points like "D4", or "pass" and "resign". Once both have passed,
+// "dead D4" marks a group, "done" accepts the marking and "resume" goes
+// back to play.
+var Game = &game.Game{
+	ID:        "go",
+	Name:      "Go",
+	Seats:     [2]string{"Black", "White"},
+	Rules:     rules{},
+	NewScreen: newScreen,
+	NewSetup:  newSetup,
+}
+
+type rules struct{}
+
+// New starts an empty board. variant is Options, or nil for Defaults.
+func (rules) New(variant any) (game.State, error) {
+	o := Defaults
+	if v, ok := variant.(Options); ok && v != (Options{}) {
+		o = v
+	}
+	if !o.Valid() {
+		return nil, fmt.Errorf("invalid game: %d×%d with %s scoring", o.Size, o.Size, o.Scoring)
+	}
+	return New(o, Black), nil
+}
+
+func (rules) Decode(data []byte) (game.State, error) {
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if !s.Valid() || len(s.Board) != s.Size*s.Size {
+		return nil, fmt.Errorf("stored board doesn't match its size")
+	}
+	return s, nil
+}
+
+func (s State) ToMove() string {
+	return s.Turn
+}
+
+func (s State) Check(seat, move string) error {
+	verb, arg, _ := strings.Cut(move, " ")
+	switch {
+	case s.Phase == PhaseFinished:
+		return fmt.Errorf("game is over")
+	case verb == "resign":
+		// Either player, at any time
+		return nil
+	case s.Phase == PhaseMarking:
+		return s.checkMarking(seat, verb, arg)
+	case seat != s.Turn:
+		return game.ErrNotYourTurn
+	case verb == "pass":
+		return nil
+	}
+	i, err := s.ParsePoint(move)
+	if err != nil {
+		return err
+	}
+	return s.Legal(i)
+}
+
+// checkMarking allows what either player may do while marking dead stones
+func (s State) checkMarking(seat, verb, arg string) error {
+	switch verb {
+	case "dead":
+		i, err := s.ParsePoint(arg)
+		if err != nil {
+			return err
+		}
+		if s.Board[i] == "" {
+			return fmt.Errorf("no stone on %s", s.PointName(i))
+		}
+	case "done":
+		for _, d := range s.Done {
+			if d == seat {
+				return fmt.Errorf("you already accepted")
+			}
+		}
+	case "resume":
+	default:
+		return fmt.Errorf("mark dead stones, accept, or resume play")
+	}
+	return nil
+}
+
+func (s State) Apply(seat, move string) game.State {
+	verb, arg, _ := strings.Cut(move, " ")
+	switch verb {
+	case "resign":
+		return Resign(s, seat)
+	case "pass":
+		return Pass(s)
+	case "dead":
+		i, _ := s.ParsePoint(arg)
+		return ToggleDead(s, i)
+	case "done":
+		return Accept(s, seat)
+	case "resume":
+		return Resume(s, seat)
+	}
+	i, _ := s.ParsePoint(move)
+	return Play(s, i)
+}
+
+func (s State) Outcome() game.Outcome {
+	return game.Outcome{Over: s.Phase == PhaseFinished, Winner: s.Winner, Reason: s.Reason}
+}
+
+func (s State) Rematch(first string) game.State {
+	return New(s.Options, first)
+}
+
+// Record exports the game as SGF with its players, rules and result
+func (s State) Record(info game.Info) string {
+	var b strings.Builder
+	place := "TermPlay"
+	if info.Code != "" {
+		place += " room " + info.Code
+	}
+	fmt.Fprintf(&b, "(;GM[1]FF[4]CA[UTF-8]AP[TermPlay]SZ[%d]KM[%s]RU[%s]\n", s.Size, formatPoints(s.Komi()), rulesName(s.Scoring))
+	fmt.Fprintf(&b, "PB[%s]PW[%s]DT[%s]PC[%s]", sgfText(info.NameX), sgfText(info.NameO),
+		time.Unix(info.StartedAt, 0).UTC().Format("2006-01-02"), sgfText(place))
+	if s.Phase == PhaseFinished {
+		result := s.Reason
+		if result == ReasonResignation {
+			result = "B+R"
+			if s.Winner == White {
+				result = "W+R"
+			}
+		}
+		fmt.Fprintf(&b, "RE[%s]", result)
+	}
+	b.WriteString("\n")
+
+	for i, m := range s.Moves {
+		if i > 0 && i%10 == 0 {
+			b.WriteString("\n")
+		}
+		color, point, _ := strings.Cut(m, " ")
+		coord := ""
+		if point != "pass" {
+			p, _ := s.ParsePoint(point)
+			coord = string(rune('a'+p%s.Size)) + string(rune('a'+p/s.Size))
+		}
+		fmt.Fprintf(&b, ";%s[%s]", color, coord)
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+func (s State) MoveCount() int {
+	return len(s.Moves)
+}
+
+// sgfText escapes a value for an SGF property
+func sgfText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `]`, `\]`).Replace(s)
+}
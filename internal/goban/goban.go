@@ -0,0 +1,405 @@
+// Package goban holds the rules for Go. Stones without liberties are
+// captured, suicide is not allowed and simple ko is enforced. Two passes in
+// a row end play; both players then mark the dead stones and the game is
+// scored by territory (Japanese) or area (Chinese) with komi.
+package goban
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Seats play the colours: X is Black and moves first, O is White
+const (
+	Black = "X"
+	White = "O"
+)
+
+// Scoring rules
+const (
+	Japanese = "japanese"
+	Chinese  = "chinese"
+)
+
+// Phases of a game. Once both players pass they agree on which stones are
+// dead before the score is counted.
+const (
+	PhasePlaying  = "playing"
+	PhaseMarking  = "marking"
+	PhaseFinished = "finished"
+)
+
+const ReasonResignation = "resignation"
+
+// Options are what a room picks when it is created
+type Options struct {
+	Size    int    `json:"size"`
+	Scoring string `json:"scoring"`
+}
+
+// Defaults is a 9×9 game with Japanese scoring
+var Defaults = Options{Size: 9, Scoring: Japanese}
+
+// Sizes are the boards offered when creating a room
+var Sizes = []int{9, 13}
+
+// Komi is the compensation White gets for moving second
+func (o Options) Komi() float64 {
+	if o.Scoring == Chinese {
+		return 7.5
+	}
+	return 6.5
+}
+
+// Valid reports whether o is a game this package can play
+func (o Options) Valid() bool {
+	return o.Size >= 5 && o.Size <= 19 && (o.Scoring == Japanese || o.Scoring == Chinese)
+}
+
+// State is a whole game. Point r,c is Board[r*Size+c], row 0 at the top.
+type State struct {
+	Options
+	Board     []string `json:"board"` // Black, White or ""
+	Turn      string   `json:"turn"`
+	Phase     string   `json:"phase"`
+	Ko        int      `json:"ko"`                  // Point the side to move may not retake, -1 for none
+	Passes    int      `json:"passes,omitempty"`    // Passes in a row
+	CapturesX int      `json:"capturesX,omitempty"` // Stones Black has taken
+	CapturesO int      `json:"capturesO,omitempty"`
+	LastMove  int      `json:"lastMove"` // Point, -1 for a pass or the start
+
+	// While marking, Dead holds the stones either player marked dead and
+	// Done the seats that accepted them. Marking again clears Done.
+	Dead []int    `json:"dead,omitempty"`
+	Done []string `json:"done,omitempty"`
+
+	Winner string   `json:"winner,omitempty"`
+	Reason string   `json:"reason,omitempty"` // Result like "B+3.5", or ReasonResignation
+	Moves  []string `json:"moves,omitempty"`  // Colour and point or pass, e.g. "B D4" or "W pass"
+}
+
+// New returns an empty board with first to move
+func New(o Options, first string) State {
+	return State{
+		Options:  o,
+		Board:    make([]string, o.Size*o.Size),
+		Turn:     first,
+		Phase:    PhasePlaying,
+		Ko:       -1,
+		LastMove: -1,
+	}
+}
+
+// columns name the board's columns; Go boards skip I
+const columns = "ABCDEFGHJKLMNOPQRST"
+
+// PointName writes point i as Go players do: a column letter and a row
+// number counted from the bottom, e.g. "D4"
+func (s State) PointName(i int) string {
+	return string(columns[i%s.Size]) + strconv.Itoa(s.Size-i/s.Size)
+}
+
+// ParsePoint reads a point written by PointName
+func (s State) ParsePoint(name string) (int, error) {
+	name = strings.ToUpper(name)
+	if len(name) < 2 {
+		return 0, fmt.Errorf("point %q is not like D4", name)
+	}
+	c := strings.IndexByte(columns[:s.Size], name[0])
+	row, err := strconv.Atoi(name[1:])
+	if c < 0 || err != nil || row < 1 || row > s.Size {
+		return 0, fmt.Errorf("point %q is off the board", name)
+	}
+	return (s.Size-row)*s.Size + c, nil
+}
+
+// neighbors are the points next to i
+func (s State) neighbors(i int) []int {
+	r, c := i/s.Size, i%s.Size
+	n := make([]int, 0, 4)
+	if r > 0 {
+		n = append(n, i-s.Size)
+	}
+	if r < s.Size-1 {
+		n = append(n, i+s.Size)
+	}
+	if c > 0 {
+		n = append(n, i-1)
+	}
+	if c < s.Size-1 {
+		n = append(n, i+1)
+	}
+	return n
+}
+
+// Group is the chain of stones connected to i, and how many liberties it
+// has
+func (s State) Group(i int) (stones []int, liberties int) {
+	color := s.Board[i]
+	seen := map[int]bool{i: true}
+	libs := map[int]bool{}
+	stack := []int{i}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		stones = append(stones, p)
+		for _, n := range s.neighbors(p) {
+			switch {
+			case s.Board[n] == "":
+				libs[n] = true
+			case s.Board[n] == color && !seen[n]:
+				seen[n] = true
+				stack = append(stack, n)
+			}
+		}
+	}
+	return stones, len(libs)
+}
+
+// Legal says why the side to move may not play point i, or nil if it may
+func (s State) Legal(i int) error {
+	if s.Board[i] != "" {
+		return fmt.Errorf("%s is taken", s.PointName(i))
+	}
+	if i == s.Ko {
+		return fmt.Errorf("%s retakes a ko; play elsewhere first", s.PointName(i))
+	}
+	next, _ := s.place(i)
+	if _, libs := next.Group(i); libs == 0 {
+		return fmt.Errorf("%s would be suicide", s.PointName(i))
+	}
+	return nil
+}
+
+// place puts the side to move's stone on i and takes whatever it leaves
+// without liberties. It returns the new board and the stones taken.
+func (s State) place(i int) (State, []int) {
+	s.Board = append([]string(nil), s.Board...)
+	s.Board[i] = s.Turn
+	var captured []int
+	for _, n := range s.neighbors(i) {
+		if s.Board[n] != opponent(s.Turn) {
+			continue
+		}
+		if stones, libs := s.Group(n); libs == 0 {
+			for _, p := range stones {
+				s.Board[p] = ""
+			}
+			captured = append(captured, stones...)
+		}
+	}
+	return s, captured
+}
+
+// Play puts the side to move's stone on i, which must be Legal, and
+// returns the new state. s itself is left alone.
+func Play(s State, i int) State {
+	mover := s.Turn
+	s, captured := s.place(i)
+	if mover == Black {
+		s.CapturesX += len(captured)
+	} else {
+		s.CapturesO += len(captured)
+	}
+
+	// Taking a single stone with a lone stone left in atari is a ko: the
+	// stone can't be retaken straight back
+	s.Ko = -1
+	if stones, libs := s.Group(i); len(captured) == 1 && len(stones) == 1 && libs == 1 {
+		s.Ko = captured[0]
+	}
+
+	s.Passes = 0
+	s.LastMove = i
+	s.Moves = append(append([]string(nil), s.Moves...), colorLetter(mover)+" "+s.PointName(i))
+	s.Turn = opponent(mover)
+	return s
+}
+
+// Pass gives the turn away. The second pass in a row ends play and starts
+// marking dead stones.
+func Pass(s State) State {
+	s.Passes++
+	s.Ko = -1
+	s.LastMove = -1
+	s.Moves = append(append([]string(nil), s.Moves...), colorLetter(s.Turn)+" pass")
+	s.Turn = opponent(s.Turn)
+	if s.Passes >= 2 {
+		s.Phase = PhaseMarking
+		s.Dead, s.Done = nil, nil
+	}
+	return s
+}
+
+// ToggleDead marks the group on i dead, or alive again if it was marked.
+// Either way both players have to accept the marking anew.
+func ToggleDead(s State, i int) State {
+	stones, _ := s.Group(i)
+	dead := make(map[int]bool)
+	for _, p := range s.Dead {
+		dead[p] = true
+	}
+	mark := !dead[i]
+	for _, p := range stones {
+		dead[p] = mark
+	}
+	s.Dead = nil
+	for p := range s.Board {
+		if dead[p] {
+			s.Dead = append(s.Dead, p)
+		}
+	}
+	s.Done = nil
+	return s
+}
+
+// Accept records that seat agrees with the marking. Once both have, the
+// game is scored.
+func Accept(s State, seat string) State {
+	s.Done = append(append([]string(nil), s.Done...), seat)
+	if len(s.Done) < 2 {
+		return s
+	}
+	black, white := s.Score()
+	s.Phase = PhaseFinished
+	switch {
+	case black > white:
+		s.Winner, s.Reason = Black, "B+"+formatPoints(black-white)
+	case white > black:
+		s.Winner, s.Reason = White, "W+"+formatPoints(white-black)
+	default:
+		s.Reason = "0"
+	}
+	return s
+}
+
+// Resume goes back to play from marking, with seat's opponent to move so
+// resuming never gains a tempo
+func Resume(s State, seat string) State {
+	s.Phase = PhasePlaying
+	s.Passes = 0
+	s.Dead, s.Done = nil, nil
+	s.Turn = opponent(seat)
+	return s
+}
+
+// Resign ends the game with seat's opponent the winner
+func Resign(s State, seat string) State {
+	s.Phase = PhaseFinished
+	s.Winner = opponent(seat)
+	s.Reason = ReasonResignation
+	return s
+}
+
+// IsDead reports whether the stone on i is marked dead
+func (s State) IsDead(i int) bool {
+	for _, p := range s.Dead {
+		if p == i {
+			return true
+		}
+	}
+	return false
+}
+
+// Territory says who owns each point once dead stones are taken off:
+// empty regions bordered by one colour only belong to it. Points of
+// neither side are "".
+func (s State) Territory() []string {
+	board := append([]string(nil), s.Board...)
+	for _, p := range s.Dead {
+		board[p] = ""
+	}
+	owner := make([]string, len(board))
+	seen := make([]bool, len(board))
+	for i := range board {
+		if board[i] != "" || seen[i] {
+			continue
+		}
+		// Flood the empty region and note which colours it touches
+		region := []int{}
+		touches := map[string]bool{}
+		stack := []int{i}
+		seen[i] = true
+		for len(stack) > 0 {
+			p := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			region = append(region, p)
+			for _, n := range s.neighbors(p) {
+				if board[n] != "" {
+					touches[board[n]] = true
+				} else if !seen[n] {
+					seen[n] = true
+					stack = append(stack, n)
+				}
+			}
+		}
+		if len(touches) != 1 {
+			continue
+		}
+		side := White
+		if touches[Black] {
+			side = Black
+		}
+		for _, p := range region {
+			owner[p] = side
+		}
+	}
+	return owner
+}
+
+// Score counts both sides under the game's rules, komi included. Japanese
+// counts territory and prisoners, dead stones among them; Chinese counts
+// territory and stones on the board.
+func (s State) Score() (black, white float64) {
+	for _, o := range s.Territory() {
+		switch o {
+		case Black:
+			black++
+		case White:
+			white++
+		}
+	}
+	for i, v := range s.Board {
+		switch {
+		case v == "":
+		case s.Scoring == Chinese && !s.IsDead(i):
+			if v == Black {
+				black++
+			} else {
+				white++
+			}
+		case s.Scoring == Japanese && s.IsDead(i):
+			// A dead stone is one more prisoner for the other side
+			if v == Black {
+				white++
+			} else {
+				black++
+			}
+		}
+	}
+	if s.Scoring == Japanese {
+		black += float64(s.CapturesX)
+		white += float64(s.CapturesO)
+	}
+	return black, white + s.Komi()
+}
+
+func formatPoints(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+// colorLetter is B or W, as SGF writes the colours
+func colorLetter(side string) string {
+	if side == Black {
+		return "B"
+	}
+	return "W"
+}
+
+func opponent(side string) string {
+	if side == Black {
+		return White
+	}
+	return Black
+}
@@ -0,0 +1,197 @@
+package goban
+
+import (
+	"testing"
+)
+
+// playAll makes each move for the side whose turn it is, or for the seat
+// before a colon, as in "X:done", failing on any that Check rejects
+func playAll(t *testing.T, s State, moves ...string) State {
+	t.Helper()
+	for _, mv := range moves {
+		seat := s.Turn
+		if len(mv) > 2 && mv[1] == ':' {
+			seat, mv = mv[:1], mv[2:]
+		}
+		if err := s.Check(seat, mv); err != nil {
+			t.Fatalf("%s %s: %v", seat, mv, err)
+		}
+		s = s.Apply(seat, mv).(State)
+	}
+	return s
+}
+
+func small(scoring string) State {
+	return New(Options{Size: 5, Scoring: scoring}, Black)
+}
+
+func TestPoints(t *testing.T) {
+	s := New(Defaults, Black)
+	tests := []struct {
+		name  string
+		point int
+	}{
+		{"A9", 0},
+		{"J9", 8},
+		{"A1", 72},
+		{"J1", 80},
+		{"E5", 40},
+	}
+	for _, tt := range tests {
+		if got := s.PointName(tt.point); got != tt.name {
+			t.Errorf("PointName(%d) = %s, want %s", tt.point, got, tt.name)
+		}
+		if got, err := s.ParsePoint(tt.name); err != nil || got != tt.point {
+			t.Errorf("ParsePoint(%s) = %d, %v, want %d", tt.name, got, err, tt.point)
+		}
+	}
+	for _, bad := range []string{"I5", "K1", "A0", "A10", "5", ""} {
+		if _, err := s.ParsePoint(bad); err == nil {
+			t.Errorf("ParsePoint(%q) took it", bad)
+		}
+	}
+}
+
+func TestKo(t *testing.T) {
+	//     A B C D E
+	//   4 . . X O .
+	//   3 . X O . O
+	//   2 . . X O .
+	//   1 X . . . .
+	s := playAll(t, small(Japanese), "C4", "D4", "B3", "E3", "C2", "D2", "A1", "C3")
+
+	// Black takes on D3, and White may not take straight back on C3
+	s = playAll(t, s, "D3")
+	c3, _ := s.ParsePoint("C3")
+	if s.Board[c3] != "" || s.CapturesX != 1 || s.Ko != c3 {
+		t.Fatalf("after D3: C3 %q, Black took %d, ko %d; want C3 taken and a ko there", s.Board[c3], s.CapturesX, s.Ko)
+	}
+	if err := s.Check(White, "C3"); err == nil {
+		t.Errorf("White retook the ko at once")
+	}
+
+	// After a move elsewhere each, it may
+	s = playAll(t, s, "A5", "B1", "C3")
+	d3, _ := s.ParsePoint("D3")
+	if s.Board[d3] != "" || s.CapturesO != 1 {
+		t.Errorf("White's retake left D3 %q with %d taken, want it taken", s.Board[d3], s.CapturesO)
+	}
+}
+
+func TestKoOnlyForSingleStones(t *testing.T) {
+	// Taking two stones is never a ko
+	//     A B C D E
+	//   5 O . . . .
+	//   4 . . X X .
+	//   3 . X O O X
+	//   2 . . X X .
+	//   1 O . . . O
+	s := playAll(t, small(Japanese), "B3", "C3", "C2", "D3", "D2", "A1", "C4", "A5", "D4", "E1", "E3")
+	if s.Ko != -1 || s.CapturesX != 2 {
+		t.Errorf("ko %d with %d taken, want no ko and 2 taken", s.Ko, s.CapturesX)
+	}
+}
+
+func TestSuicide(t *testing.T) {
+	tests := []struct {
+		name  string
+		moves []string
+		last  string
+		ok    bool
+	}{
+		// White holds A2 and B1, so A1 has no liberty left
+		{"into a corner", []string{"E5", "A2", "E4", "B1"}, "A1", false},
+		// White holds A3 and B2 round A2, but Black's stone there takes A1
+		{"capturing is not suicide", []string{"B1", "A1", "E5", "A3", "E4", "B2"}, "A2", true},
+		// Filling a group's own last liberty
+		{"whole group", []string{"A1", "A3", "B1", "B2", "E5", "C1"}, "A2", false},
+		{"taken point", []string{"C3"}, "C3", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := playAll(t, small(Japanese), tt.moves...)
+			if err := s.Check(s.Turn, tt.last); (err == nil) != tt.ok {
+				t.Errorf("Check(%s) = %v, want ok %v", tt.last, err, tt.ok)
+			}
+		})
+	}
+}
+
+// walls has Black build a wall down column black and White one down
+// column white on the 5×5 board, then both pass
+func walls(black, white byte, extra ...string) []string {
+	var moves []string
+	for row := '1'; row <= '5'; row++ {
+		moves = append(moves, string(black)+string(row), string(white)+string(row))
+	}
+	moves = append(moves, extra...)
+	return append(moves, "pass", "pass")
+}
+
+func TestScoring(t *testing.T) {
+	// Black walls off column D while White only passes
+	alone := []string{"D1", "pass", "D2", "pass", "D3", "pass", "D4", "pass", "D5", "pass", "pass"}
+	tests := []struct {
+		name    string
+		scoring string
+		moves   []string
+		marks   []string
+		black   float64
+		white   float64
+		reason  string
+	}{
+		// Black owns columns A and B, White column E
+		{"territory", Japanese, walls('C', 'D'), nil, 10, 11.5, "W+1.5"},
+		{"area", Chinese, walls('C', 'D'), nil, 15, 17.5, "W+2.5"},
+		// White never plays, so the whole board is Black's
+		{"territory, Black ahead", Japanese, alone, nil, 20, 6.5, "B+13.5"},
+		{"area, Black ahead", Chinese, alone, nil, 25, 7.5, "B+17.5"},
+
+		// White's stone on A3 is marked dead: a prisoner under Japanese
+		// rules, and simply gone under Chinese ones
+		{"territory with a dead stone", Japanese, walls('C', 'D', "pass", "A3"), []string{"X:dead A3"}, 11, 11.5, "W+0.5"},
+		{"area with a dead stone", Chinese, walls('C', 'D', "pass", "A3"), []string{"X:dead A3"}, 15, 17.5, "W+2.5"},
+
+		// Left alive, it spoils Black's territory instead
+		{"territory with a live stone", Japanese, walls('C', 'D', "pass", "A3"), nil, 0, 11.5, "W+11.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := playAll(t, small(tt.scoring), tt.moves...)
+			if s.Phase != PhaseMarking {
+				t.Fatalf("phase %s after two passes, want %s", s.Phase, PhaseMarking)
+			}
+			s = playAll(t, s, tt.marks...)
+			if black, white := s.Score(); black != tt.black || white != tt.white {
+				t.Errorf("Score = %v, %v, want %v, %v", black, white, tt.black, tt.white)
+			}
+			s = playAll(t, s, "X:done", "O:done")
+			if out := s.Outcome(); !out.Over || out.Reason != tt.reason {
+				t.Errorf("Outcome = %+v, want over with %s", out, tt.reason)
+			}
+		})
+	}
+}
+
+func TestMarkingNeedsBothAgain(t *testing.T) {
+	s := playAll(t, small(Japanese), walls('C', 'D', "pass", "A3")...)
+	s = playAll(t, s, "O:done", "X:dead A3")
+	if len(s.Done) != 0 {
+		t.Errorf("marking kept %v's acceptance", s.Done)
+	}
+	s = playAll(t, s, "X:done")
+	if s.Phase != PhaseMarking {
+		t.Errorf("game scored with only Black accepting")
+	}
+	if err := s.Check(Black, "done"); err == nil {
+		t.Errorf("Black accepted twice")
+	}
+}
+
+func TestResumeGivesTheTurnAway(t *testing.T) {
+	s := playAll(t, small(Japanese), walls('C', 'D')...)
+	s = playAll(t, s, "X:resume")
+	if s.Phase != PhasePlaying || s.Turn != White {
+		t.Errorf("after Black resumed: phase %s, %s to move; want playing, White", s.Phase, s.Turn)
+	}
+}
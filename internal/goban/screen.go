@@ -0,0 +1,301 @@
+package goban
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// screen is the board cursor, and whether the player is being asked to
+// confirm resigning
+type screen struct {
+	synced    bool
+	r, c      int
+	resigning bool
+}
+
+func newScreen() game.Screen {
+	return screen{}
+}
+
+// Sync puts the cursor on the centre point when the first snapshot arrives
+func (sc screen) Sync(v game.View) game.Screen {
+	if !sc.synced {
+		s := v.State.(State)
+		sc.synced = true
+		sc.r, sc.c = s.Size/2, s.Size/2
+	}
+	return sc
+}
+
+func (sc screen) Update(v game.View, msg tea.KeyMsg) (game.Screen, game.Action) {
+	s := v.State.(State)
+	if sc.resigning {
+		switch msg.String() {
+		case "y", "Y", "enter":
+			sc.resigning = false
+			return sc, game.Action{Move: "resign"}
+		case "n", "N", "esc":
+			sc.resigning = false
+		}
+		return sc, game.Action{}
+	}
+
+	seated := v.Seat != "" && v.Info.Status == "playing"
+	i := sc.r*s.Size + sc.c
+	switch msg.String() {
+	case "up", "k":
+		sc.r = max(sc.r-1, 0)
+	case "down", "j":
+		sc.r = min(sc.r+1, s.Size-1)
+	case "left", "h":
+		sc.c = max(sc.c-1, 0)
+	case "right", "l":
+		sc.c = min(sc.c+1, s.Size-1)
+	case "s":
+		// The game so far as SGF, for players and spectators alike
+		return sc, game.Action{Share: &game.Share{Title: "GAME (SGF)", Text: s.Record(v.Info)}}
+	case "R":
+		if seated {
+			sc.resigning = true
+		}
+	case "enter", " ":
+		switch {
+		case s.Phase == PhaseMarking && seated && s.Board[i] != "":
+			return sc, game.Action{Move: "dead " + s.PointName(i)}
+		case s.Phase == PhasePlaying && v.MyTurn() && s.Legal(i) == nil:
+			return sc, game.Action{Move: s.PointName(i)}
+		}
+	case "p":
+		if s.Phase == PhasePlaying && v.MyTurn() {
+			return sc, game.Action{Move: "pass"}
+		}
+	case "d":
+		if s.Phase == PhaseMarking && seated {
+			return sc, game.Action{Move: "done"}
+		}
+	case "u":
+		if s.Phase == PhaseMarking && seated {
+			return sc, game.Action{Move: "resume"}
+		}
+	default:
+		return sc, game.Action{Unhandled: true}
+	}
+	return sc, game.Action{}
+}
+
+// Modal asks before resigning
+func (sc screen) Modal(v game.View) string {
+	if !sc.resigning {
+		return ""
+	}
+	return lipgloss.JoinVertical(lipgloss.Center,
+		styles.Title.Render("RESIGN?"),
+		"",
+		"The game ends and "+v.Game.SeatName(game.Other(v.Seat))+" wins.",
+		"\n",
+		styles.Subtle.Render("[Y] Resign • [N] Keep playing"),
+	)
+}
+
+func (sc screen) Help(v game.View) string {
+	s := v.State.(State)
+	if s.Phase == PhaseMarking {
+		return "arrows/hjkl move • space mark dead • d accept • u resume play • s SGF • q quit"
+	}
+	return "arrows/hjkl move • enter/space place • p pass • R resign • s SGF • r restart • q quit"
+}
+
+func (sc screen) Render(v game.View) string {
+	s := v.State.(State)
+	sqW, sqH := game.SquareSize(s.Size, v.Width, v.Height)
+
+	// Territory is shown once play has stopped, with dead stones taken off
+	var owner []string
+	if s.Phase == PhaseMarking || (s.Phase == PhaseFinished && s.Reason != ReasonResignation) {
+		owner = s.Territory()
+	}
+
+	files := make([]string, s.Size)
+	ranks := make([]string, s.Size)
+	for i := range s.Size {
+		files[i] = string(columns[i])
+		ranks[i] = fmt.Sprint(s.Size - i)
+	}
+	grid := game.LabeledGrid(files, ranks, sqW, sqH, func(r, c int) string {
+		i := r*s.Size + c
+		bg := styles.GoBoard
+		switch {
+		case r == sc.r && c == sc.c:
+			bg = game.CursorSquare
+		case i == s.LastMove:
+			bg = styles.ChessHighlight
+		}
+
+		mark, fg := "", styles.GoLine
+		switch {
+		case s.Board[i] != "" && s.IsDead(i):
+			mark, fg = "✕", stoneColor(s.Board[i])
+		case s.Board[i] != "":
+			mark, fg = "●", stoneColor(s.Board[i])
+		case owner != nil && owner[i] != "":
+			mark, fg = "▪", stoneColor(owner[i])
+		}
+		return renderPoint(s, r, c, mark, fg, bg, sqW, sqH)
+	})
+
+	info := fmt.Sprintf("Captures: %s %d  ·  %s %d  ·  Komi %s  ·  %s rules",
+		v.Game.SeatName(Black), s.CapturesX, v.Game.SeatName(White), s.CapturesO,
+		formatPoints(s.Komi()), rulesName(s.Scoring))
+
+	return game.BoardFrame(lipgloss.JoinVertical(lipgloss.Center,
+		styles.Title.Render("GO"),
+		game.Scoreboard(v),
+		styles.Highlight.Render(info),
+		"",
+		grid,
+		"",
+		renderStatus(v, s),
+	))
+}
+
+// renderPoint draws one intersection: the board's lines running off to its
+// neighbours, with a stone or marker on the crossing
+func renderPoint(s State, r, c int, mark string, fg, bg lipgloss.Color, sqW, sqH int) string {
+	n := s.Size
+	up, down, left, right := r > 0, r < n-1, c > 0, c < n-1
+	lineStyle := lipgloss.NewStyle().Background(bg).Foreground(styles.GoLine)
+	markStyle := lipgloss.NewStyle().Background(bg).Foreground(fg).Bold(true)
+
+	cx, cy := (sqW-1)/2, (sqH-1)/2
+	rows := make([]string, sqH)
+	for y := range sqH {
+		if y != cy {
+			// Above or below the crossing only the vertical line shows
+			line := " "
+			if (y < cy && up) || (y > cy && down) {
+				line = "│"
+			}
+			rows[y] = lineStyle.Render(strings.Repeat(" ", cx) + line + strings.Repeat(" ", sqW-cx-1))
+			continue
+		}
+		l, rt := strings.Repeat(" ", cx), strings.Repeat(" ", sqW-cx-1)
+		if left {
+			l = strings.Repeat("─", cx)
+		}
+		if right {
+			rt = strings.Repeat("─", sqW-cx-1)
+		}
+		center := markStyle.Render(mark)
+		if mark == "" {
+			center = lineStyle.Render(junction(up, down, left, right, isStar(n, r, c)))
+		}
+		rows[y] = lineStyle.Render(l) + center + lineStyle.Render(rt)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// junction is the box-drawing character where the lines meet
+func junction(up, down, left, right, star bool) string {
+	switch {
+	case star:
+		return "╋"
+	case !up && !left:
+		return "┌"
+	case !up && !right:
+		return "┐"
+	case !down && !left:
+		return "└"
+	case !down && !right:
+		return "┘"
+	case !up:
+		return "┬"
+	case !down:
+		return "┴"
+	case !left:
+		return "├"
+	case !right:
+		return "┤"
+	}
+	return "┼"
+}
+
+// isStar reports whether r,c is one of the board's marked points: the
+// centre, and the 3-3 points on 9×9 or the 4-4 points on larger boards
+func isStar(n, r, c int) bool {
+	edge := 3
+	if n < 13 {
+		edge = 2
+	}
+	onLine := func(i int) bool {
+		return i == edge || i == n-1-edge || (n%2 == 1 && i == n/2)
+	}
+	if n < 19 && (r == n/2) != (c == n/2) {
+		// Only the full board has stars along the sides
+		return false
+	}
+	return onLine(r) && onLine(c)
+}
+
+func stoneColor(side string) lipgloss.Color {
+	if side == Black {
+		return styles.CheckersBlackPiece
+	}
+	return styles.CheckersWhitePiece
+}
+
+func rulesName(scoring string) string {
+	if scoring == Chinese {
+		return "Chinese"
+	}
+	return "Japanese"
+}
+
+// renderStatus is whose turn it is or how the game ended, or the marking
+// in progress with its score so far, followed by the last move
+func renderStatus(v game.View, s State) string {
+	var lines []string
+	switch s.Phase {
+	case PhaseMarking:
+		lines = append(lines, styles.Special.Render("SCORING: mark the dead stones, then accept"))
+		black, white := s.Score()
+		lines = append(lines, fmt.Sprintf("%s %s  ·  %s %s",
+			v.Game.SeatName(Black), formatPoints(black), v.Game.SeatName(White), formatPoints(white)))
+		if len(s.Done) > 0 {
+			lines = append(lines, styles.Subtle.Render(v.Game.SeatName(s.Done[0])+" has accepted"))
+		}
+	case PhaseFinished:
+		lines = append(lines, game.StatusLine(v, v.Game.SeatName(s.Turn)))
+		if s.Reason == ReasonResignation {
+			lines = append(lines, styles.Subtle.Render(v.Game.SeatName(game.Other(s.Winner))+" resigned"))
+		} else {
+			black, white := s.Score()
+			lines = append(lines, styles.Subtle.Render(fmt.Sprintf("Final score %s–%s (%s)",
+				formatPoints(black), formatPoints(white), s.Reason)))
+		}
+	default:
+		lines = append(lines, game.StatusLine(v, v.Game.SeatName(s.Turn)))
+		if s.Passes > 0 && v.Info.Status == "playing" {
+			lines = append(lines, styles.Subtle.Render(v.Game.SeatName(game.Other(s.Turn))+" passed"))
+		}
+	}
+	if len(s.Moves) > 0 {
+		lines = append(lines, styles.Subtle.Render("Last move: "+moveText(v, s.Moves[len(s.Moves)-1])))
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, lines...)
+}
+
+// moveText spells out a recorded move, e.g. "B D4" as "Black D4"
+func moveText(v game.View, m string) string {
+	color, point, _ := strings.Cut(m, " ")
+	side := White
+	if color == "B" {
+		side = Black
+	}
+	return v.Game.SeatName(side) + " " + point
+}
@@ -0,0 +1,99 @@
+package goban
+
+import (
+	"strconv"
+
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// scorings are the rules offered, in the order shown
+var scorings = []struct{ Name, ID string }{
+	{"Japanese", Japanese},
+	{"Chinese", Chinese},
+}
+
+// setup picks the board size and the scoring rules
+type setup struct {
+	field   int // 0: board size, 1: scoring
+	size    int // into Sizes
+	scoring int // into scorings
+}
+
+func newSetup() game.Setup {
+	return setup{}
+}
+
+func (st setup) Update(msg tea.KeyMsg) (game.Setup, tea.Cmd) {
+	step := 0
+	switch msg.String() {
+	case "tab", "shift+tab":
+		st.field = 1 - st.field
+	case "left", "h":
+		step = -1
+	case "right", "l":
+		step = 1
+	}
+	if st.field == 0 {
+		st.size = (st.size + len(Sizes) + step) % len(Sizes)
+	} else {
+		st.scoring = (st.scoring + len(scorings) + step) % len(scorings)
+	}
+	return st, nil
+}
+
+func (st setup) View() string {
+	dimmed := lipgloss.Color("#3d4d5c")
+
+	var sizes []string
+	for i, n := range Sizes {
+		label := strconv.Itoa(n) + "×" + strconv.Itoa(n)
+		sizes = append(sizes, game.RenderPreset(label, i == st.size))
+	}
+	sizeBox := styles.ListContainer.Width(66).Align(lipgloss.Center)
+	if st.field != 0 {
+		sizeBox = sizeBox.BorderForeground(dimmed)
+	}
+
+	var rules []string
+	for i, r := range scorings {
+		rules = append(rules, game.RenderPreset(r.Name, i == st.scoring))
+	}
+	o := Options{Scoring: scorings[st.scoring].ID}
+	rulesView := lipgloss.JoinVertical(lipgloss.Center,
+		lipgloss.JoinHorizontal(lipgloss.Center, rules...),
+		"",
+		styles.Subtle.Render(scoringHint(o)),
+	)
+	rulesBox := styles.ListContainer.Width(66).Align(lipgloss.Center)
+	if st.field != 1 {
+		rulesBox = rulesBox.BorderForeground(dimmed)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Center,
+		"Board Size:",
+		sizeBox.Render(lipgloss.JoinHorizontal(lipgloss.Center, sizes...)),
+		"",
+		"Scoring:",
+		rulesBox.Render(rulesView),
+	)
+}
+
+// scoringHint says in a line what the rules count
+func scoringHint(o Options) string {
+	if o.Scoring == Chinese {
+		return "Area: stones plus territory, komi " + formatPoints(o.Komi())
+	}
+	return "Territory plus prisoners, komi " + formatPoints(o.Komi())
+}
+
+func (st setup) Help() string {
+	return "←/→: Change • Tab: Switch Field"
+}
+
+func (st setup) Settings() (game.Settings, error) {
+	return game.Settings{Variant: Options{Size: Sizes[st.size], Scoring: scorings[st.scoring].ID}}, nil
+}
@@ -0,0 +1,111 @@
+package othello
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/ai"
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Levels are the strengths offered for Othello, weakest first
+var Levels = []ai.Level{
+	{Name: "Easy", Depth: 1, Budget: time.Second, Noise: 60},
+	{Name: "Medium", Depth: 3, Budget: 2 * time.Second, Noise: 10},
+	{Name: "Hard", Depth: 6, Budget: 4 * time.Second},
+}
+
+// engine plays the computer's side in solo games
+type engine struct{}
+
+func (engine) Levels() []ai.Level {
+	return Levels
+}
+
+func (engine) Move(ctx context.Context, s game.State, lvl ai.Level) (string, bool) {
+	cell, ok := ai.Search[State, int](ctx, searcher{}, s.(State), lvl)
+	if !ok || cell == pass {
+		return "", false
+	}
+	return SquareName(Pos{Row: cell / 8, Col: cell % 8}), true
+}
+
+// searcher lets the search play Othello through Flips. Moves are square
+// indexes, row*8+col. The search expects the turn to change on every
+// move, so a pass is a move of its own rather than automatic as in Play.
+type searcher struct{}
+
+const pass = -1
+
+// weights rate each square: corners can never be flipped back, and the
+// squares next to them give the corner away
+var weights = [8][8]int{
+	{100, -20, 10, 5, 5, 10, -20, 100},
+	{-20, -50, -2, -2, -2, -2, -50, -20},
+	{10, -2, 1, 1, 1, 1, -2, 10},
+	{5, -2, 1, 0, 0, 1, -2, 5},
+	{5, -2, 1, 0, 0, 1, -2, 5},
+	{10, -2, 1, 1, 1, 1, -2, 10},
+	{-20, -50, -2, -2, -2, -2, -50, -20},
+	{100, -20, 10, 5, 5, 10, -20, 100},
+}
+
+func (searcher) Moves(s State) []int {
+	mine := s.LegalMoves(s.Turn)
+	if len(mine) == 0 {
+		if len(s.LegalMoves(opponent(s.Turn))) == 0 {
+			return nil
+		}
+		return []int{pass}
+	}
+	// Best squares first
+	sort.SliceStable(mine, func(i, j int) bool {
+		return weights[mine[i].Row][mine[i].Col] > weights[mine[j].Row][mine[j].Col]
+	})
+	moves := make([]int, len(mine))
+	for i, p := range mine {
+		moves[i] = p.Row*8 + p.Col
+	}
+	return moves
+}
+
+func (searcher) Play(s State, cell int) State {
+	if cell != pass {
+		s = place(s, s.Turn, Pos{Row: cell / 8, Col: cell % 8})
+	}
+	s.Turn = opponent(s.Turn)
+	return s
+}
+
+func (searcher) Score(s State) (int, bool) {
+	mine, theirs := len(s.LegalMoves(s.Turn)), len(s.LegalMoves(opponent(s.Turn)))
+	if mine == 0 && theirs == 0 {
+		black, white := s.Count()
+		diff := black - white
+		if s.Turn == White {
+			diff = -diff
+		}
+		switch {
+		case diff > 0:
+			return ai.Win, true
+		case diff < 0:
+			return ai.Loss, true
+		}
+		return 0, true
+	}
+
+	// Good squares held, plus having more moves to choose from
+	score := 10 * (mine - theirs)
+	for r := 0; r < 8; r++ {
+		for c := 0; c < 8; c++ {
+			switch s.Board[r][c] {
+			case s.Turn:
+				score += weights[r][c]
+			case opponent(s.Turn):
+				score -= weights[r][c]
+			}
+		}
+	}
+	return score, false
+}
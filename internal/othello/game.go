@@ -0,0 +1,73 @@
+package othello
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Game is Othello. The host (X) plays Black and the guest (O) White. Moves
+// are squares, e.g. "d3"; passes happen by themselves.
+var Game = &game.Game{
+	ID:        "othello",
+	Name:      "Othello",
+	Seats:     [2]string{"Black", "White"},
+	Rules:     rules{},
+	NewScreen: newScreen,
+	Engine:    engine{},
+}
+
+type rules struct{}
+
+func (rules) New(variant any) (game.State, error) {
+	return New(Black), nil
+}
+
+func (rules) Decode(data []byte) (game.State, error) {
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if s.Turn != Black && s.Turn != White {
+		return nil, fmt.Errorf("stored game has no side to move")
+	}
+	return s, nil
+}
+
+func (s State) ToMove() string {
+	return s.Turn
+}
+
+func (s State) Check(seat, move string) error {
+	if s.Status != "playing" {
+		return fmt.Errorf("game is over")
+	}
+	if seat != s.Turn {
+		return game.ErrNotYourTurn
+	}
+	p, err := ParseSquare(move)
+	if err != nil {
+		return err
+	}
+	if s.Board[p.Row][p.Col] != "" {
+		return fmt.Errorf("%s is taken", move)
+	}
+	if len(s.Flips(seat, p)) == 0 {
+		return fmt.Errorf("%s doesn't outflank anything", move)
+	}
+	return nil
+}
+
+func (s State) Apply(seat, move string) game.State {
+	p, _ := ParseSquare(move)
+	return Play(s, p)
+}
+
+func (s State) Outcome() game.Outcome {
+	return game.Outcome{Over: s.Status != "playing", Winner: s.Winner}
+}
+
+func (s State) Rematch(first string) game.State {
+	return New(first)
+}
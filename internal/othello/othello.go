@@ -0,0 +1,152 @@
+// Package othello holds the rules for Othello (Reversi): each disc placed
+// must outflank a line of the opponent's discs, which all flip over. A side
+// with no such move passes, and once neither can move the most discs win.
+package othello
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Seats play the colours: X is Black and moves first, O is White
+const (
+	Black = "X"
+	White = "O"
+)
+
+// Pos is a square, row 0 at the top
+type Pos struct {
+	Row, Col int
+}
+
+func (p Pos) onBoard() bool {
+	return p.Row >= 0 && p.Row < 8 && p.Col >= 0 && p.Col < 8
+}
+
+// SquareName writes p as Othello players do: a file letter and a row
+// number counted from the top, e.g. "d3"
+func SquareName(p Pos) string {
+	return string(rune('a'+p.Col)) + strconv.Itoa(p.Row+1)
+}
+
+// ParseSquare reads a square written by SquareName
+func ParseSquare(s string) (Pos, error) {
+	if len(s) != 2 || s[0] < 'a' || s[0] > 'h' || s[1] < '1' || s[1] > '8' {
+		return Pos{}, fmt.Errorf("square %q is not like d3", s)
+	}
+	return Pos{Row: int(s[1] - '1'), Col: int(s[0] - 'a')}, nil
+}
+
+// State is a whole game. Squares hold Black, White or "".
+type State struct {
+	Board  [8][8]string `json:"board"`
+	Turn   string       `json:"turn"`
+	Status string       `json:"status"`           // "playing" or "finished"
+	Winner string       `json:"winner,omitempty"` // "" for a draw
+	Passed string       `json:"passed,omitempty"` // Side that had no move last turn
+	Moves  []string     `json:"moves,omitempty"`
+}
+
+// New returns the usual four-disc start with first to move
+func New(first string) State {
+	s := State{Turn: first, Status: "playing"}
+	s.Board[3][3], s.Board[4][4] = White, White
+	s.Board[3][4], s.Board[4][3] = Black, Black
+	return s
+}
+
+var directions = [8][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+
+// Flips lists the discs side would turn over by playing p. A move is legal
+// exactly when it flips something.
+func (s State) Flips(side string, p Pos) []Pos {
+	if !p.onBoard() || s.Board[p.Row][p.Col] != "" {
+		return nil
+	}
+	var flips []Pos
+	for _, d := range directions {
+		var line []Pos
+		q := Pos{Row: p.Row + d[0], Col: p.Col + d[1]}
+		for q.onBoard() && s.Board[q.Row][q.Col] == opponent(side) {
+			line = append(line, q)
+			q = Pos{Row: q.Row + d[0], Col: q.Col + d[1]}
+		}
+		if len(line) > 0 && q.onBoard() && s.Board[q.Row][q.Col] == side {
+			flips = append(flips, line...)
+		}
+	}
+	return flips
+}
+
+// LegalMoves lists the squares side may play
+func (s State) LegalMoves(side string) []Pos {
+	var moves []Pos
+	for r := 0; r < 8; r++ {
+		for c := 0; c < 8; c++ {
+			p := Pos{Row: r, Col: c}
+			if len(s.Flips(side, p)) > 0 {
+				moves = append(moves, p)
+			}
+		}
+	}
+	return moves
+}
+
+// Count is how many discs each side has
+func (s State) Count() (black, white int) {
+	for r := 0; r < 8; r++ {
+		for c := 0; c < 8; c++ {
+			switch s.Board[r][c] {
+			case Black:
+				black++
+			case White:
+				white++
+			}
+		}
+	}
+	return black, white
+}
+
+// place puts side's disc on p and flips what it outflanks, without
+// touching whose turn it is
+func place(s State, side string, p Pos) State {
+	for _, f := range s.Flips(side, p) {
+		s.Board[f.Row][f.Col] = side
+	}
+	s.Board[p.Row][p.Col] = side
+	return s
+}
+
+// Play puts the side to move's disc on p, which must flip something, and
+// returns the new state. If the opponent then has no move they pass, and if
+// neither side can move the game is scored. s itself is left alone.
+func Play(s State, p Pos) State {
+	mover := s.Turn
+	s = place(s, mover, p)
+	s.Moves = append(append([]string(nil), s.Moves...), SquareName(p))
+	s.Passed = ""
+
+	switch {
+	case len(s.LegalMoves(opponent(mover))) > 0:
+		s.Turn = opponent(mover)
+	case len(s.LegalMoves(mover)) > 0:
+		s.Passed = opponent(mover)
+	default:
+		s.Status = "finished"
+		black, white := s.Count()
+		switch {
+		case black > white:
+			s.Winner = Black
+		case white > black:
+			s.Winner = White
+		}
+	}
+	return s
+}
+
+func opponent(side string) string {
+	if side == Black {
+		return White
+	}
+	return Black
+}
@@ -0,0 +1,115 @@
+package othello
+
+import (
+	"fmt"
+
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// screen is the board cursor
+type screen struct {
+	r, c int
+}
+
+func newScreen() game.Screen {
+	return screen{r: 2, c: 3}
+}
+
+func (sc screen) Sync(v game.View) game.Screen {
+	return sc
+}
+
+func (sc screen) Update(v game.View, msg tea.KeyMsg) (game.Screen, game.Action) {
+	s := v.State.(State)
+	switch msg.String() {
+	case "up", "k":
+		sc.r = max(sc.r-1, 0)
+	case "down", "j":
+		sc.r = min(sc.r+1, 7)
+	case "left", "h":
+		sc.c = max(sc.c-1, 0)
+	case "right", "l":
+		sc.c = min(sc.c+1, 7)
+	case "enter", " ":
+		p := Pos{Row: sc.r, Col: sc.c}
+		if v.MyTurn() && len(s.Flips(v.Seat, p)) > 0 {
+			return sc, game.Action{Move: SquareName(p)}
+		}
+	default:
+		return sc, game.Action{Unhandled: true}
+	}
+	return sc, game.Action{}
+}
+
+func (sc screen) Help(v game.View) string {
+	return "arrows/hjkl move • enter/space place • r restart • q quit"
+}
+
+func (sc screen) Render(v game.View) string {
+	s := v.State.(State)
+	sqW, sqH := game.SquareSize(8, v.Width, v.Height)
+
+	// Only the player whose turn it is sees where they may go
+	valid := make(map[Pos]bool)
+	if v.MyTurn() {
+		for _, p := range s.LegalMoves(v.Seat) {
+			valid[p] = true
+		}
+	}
+
+	files := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	ranks := []string{"1", "2", "3", "4", "5", "6", "7", "8"}
+	grid := game.LabeledGrid(files, ranks, sqW, sqH, func(r, c int) string {
+		bg := styles.ChessDarkSquare
+		if valid[Pos{Row: r, Col: c}] {
+			bg = styles.ChessHighlight
+		}
+		if r == sc.r && c == sc.c {
+			bg = game.CursorSquare
+		}
+
+		disc := ""
+		fg := styles.CheckersBlackPiece
+		switch s.Board[r][c] {
+		case Black:
+			disc = "●"
+		case White:
+			disc, fg = "●", styles.CheckersWhitePiece
+		}
+		return game.Square(disc, fg, bg, sqW, sqH)
+	})
+
+	black, white := s.Count()
+	count := fmt.Sprintf("Discs: %s %d  ·  %s %d", v.Game.SeatName(Black), black, v.Game.SeatName(White), white)
+
+	return game.BoardFrame(lipgloss.JoinVertical(lipgloss.Center,
+		styles.Title.Render("OTHELLO"),
+		game.Scoreboard(v),
+		styles.Highlight.Render(count),
+		"",
+		grid,
+		"",
+		renderStatus(v, s),
+	))
+}
+
+// renderStatus is the usual status line, with the final score or any pass
+// just made, and the last move
+func renderStatus(v game.View, s State) string {
+	lines := []string{game.StatusLine(v, v.Game.SeatName(s.Turn))}
+	switch {
+	case s.Status == "finished":
+		black, white := s.Count()
+		lines = append(lines, styles.Subtle.Render(fmt.Sprintf("Final score %d–%d", black, white)))
+	case s.Passed != "" && v.Info.Status == "playing":
+		lines = append(lines, styles.Subtle.Render(v.Game.SeatName(s.Passed)+" has no move and passes"))
+	}
+	if len(s.Moves) > 0 {
+		lines = append(lines, styles.Subtle.Render("Last move: "+s.Moves[len(s.Moves)-1]))
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, lines...)
+}
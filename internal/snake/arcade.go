@@ -0,0 +1,41 @@
+package snake
+
+import (
+	"github.com/aminshahid573/termplay/internal/game"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Game is single-player Snake, played without a room
+var Game = &game.Game{
+	ID:        "snake",
+	Name:      "Snake",
+	NewArcade: newArcade,
+}
+
+// arcade runs a Model for the game menu
+type arcade struct {
+	m Model
+}
+
+func newArcade(width, height int, player game.Player) (game.Arcade, tea.Cmd) {
+	m := InitialModel()
+	m.TermW, m.TermH = width, height
+	m.scores, m.library = player.Scores, player.Library
+	return arcade{m: m}, tea.Batch(TickCmd(), m.loadBoardsCmd(), m.loadLevelsCmd())
+}
+
+func (a arcade) Update(msg tea.Msg) (game.Arcade, tea.Cmd) {
+	var cmd tea.Cmd
+	a.m, cmd = a.m.Update(msg)
+	return a, cmd
+}
+
+func (a arcade) View(width, height int) string {
+	a.m.TermW, a.m.TermH = width, height
+	return a.m.View()
+}
+
+func (a arcade) Done() bool {
+	return a.m.WantsQuit
+}
@@ -0,0 +1,325 @@
+package snake
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Arena is Snake for up to eight players on one board, in a room. The
+// server steps it; players join a round with enter and steer as usual.
+var Arena = &game.Game{
+	ID:      "snake-arena",
+	Name:    "Snake Arena",
+	NewLive: newArena,
+}
+
+const (
+	arenaW, arenaH  = 36, 20
+	arenaTick       = 120 * time.Millisecond
+	arenaMaxPlayers = 8
+	arenaMinPlayers = 2
+
+	// Ticks spent counting down to a round, and showing its result
+	countdownTicks = 25
+	resultTicks    = 33
+)
+
+// Phases of the arena. It waits until two players have joined, counts
+// down, runs the round until one snake is left, shows the result and
+// counts down to the next.
+const (
+	arenaWaiting   = "waiting"
+	arenaCountdown = "countdown"
+	arenaRunning   = "running"
+	arenaOver      = "over"
+)
+
+// arenaPlayer is one player's snake. Players who join mid-round wait, not
+// alive, for the next one.
+type arenaPlayer struct {
+	ID, Name string
+	Color    int // into arenaColors
+	Body     []Point
+	Dir      Direction
+	NextDir  Direction
+	Alive    bool
+	Waiting  bool // Joined mid-round, so plays from the next
+	Score    int  // This round
+	Wins     int
+}
+
+// arena is the whole game. Only the hub's goroutine for the room touches
+// it; sessions get copies through Frame.
+type arena struct {
+	phase   string
+	timer   int // Ticks left in a countdown or on the result
+	players []*arenaPlayer
+	food    []Point
+	winner  string // Name of the last round's winner, "" for a draw
+	frame   int
+	rng     *rand.Rand
+}
+
+func newArena() game.Live {
+	return &arena{phase: arenaWaiting, rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+func (a *arena) Tick() time.Duration {
+	return arenaTick
+}
+
+// spawns are where snakes start, heading into the board: two on each side
+var spawns = []struct {
+	At  Point
+	Dir Direction
+}{
+	{Point{3, 4}, DirRight},
+	{Point{arenaW - 4, arenaH - 5}, DirLeft},
+	{Point{arenaW - 4, 4}, DirLeft},
+	{Point{3, arenaH - 5}, DirRight},
+	{Point{12, 2}, DirDown},
+	{Point{arenaW - 13, arenaH - 3}, DirUp},
+	{Point{arenaW - 13, 2}, DirDown},
+	{Point{12, arenaH - 3}, DirUp},
+}
+
+func (a *arena) player(pid string) *arenaPlayer {
+	for _, p := range a.players {
+		if p.ID == pid {
+			return p
+		}
+	}
+	return nil
+}
+
+func (a *arena) Input(pid, name, key string) {
+	p := a.player(pid)
+	switch key {
+	case "enter", " ":
+		if p == nil && len(a.players) < arenaMaxPlayers {
+			a.join(pid, name)
+		}
+	case "x":
+		a.Leave(pid)
+	case "up", "w", "k":
+		p.steer(DirUp, DirDown)
+	case "down", "s", "j":
+		p.steer(DirDown, DirUp)
+	case "left", "a", "h":
+		p.steer(DirLeft, DirRight)
+	case "right", "d", "l":
+		p.steer(DirRight, DirLeft)
+	}
+}
+
+// steer turns the snake on the next step, unless that would reverse it
+// into itself
+func (p *arenaPlayer) steer(dir, opposite Direction) {
+	if p != nil && p.Alive && p.Dir != opposite {
+		p.NextDir = dir
+	}
+}
+
+// join adds a player with the first free colour. Between rounds their
+// snake goes straight onto the board.
+func (a *arena) join(pid, name string) {
+	used := make(map[int]bool)
+	for _, p := range a.players {
+		used[p.Color] = true
+	}
+	color := 0
+	for used[color] {
+		color++
+	}
+	a.players = append(a.players, &arenaPlayer{ID: pid, Name: name, Color: color, Waiting: a.phase == arenaRunning})
+	if a.phase == arenaCountdown {
+		a.placeSnakes()
+	}
+}
+
+func (a *arena) Leave(pid string) {
+	for i, p := range a.players {
+		if p.ID == pid {
+			a.players = append(a.players[:i], a.players[i+1:]...)
+			break
+		}
+	}
+	if a.phase == arenaCountdown {
+		a.placeSnakes()
+	}
+}
+
+// placeSnakes puts every player's snake on its spawn for a new round,
+// with fresh food
+func (a *arena) placeSnakes() {
+	for i, p := range a.players {
+		s := spawns[i]
+		back := map[Direction]Point{DirRight: {-1, 0}, DirLeft: {1, 0}, DirDown: {0, -1}, DirUp: {0, 1}}[s.Dir]
+		p.Body = []Point{s.At, {s.At.X + back.X, s.At.Y + back.Y}, {s.At.X + 2*back.X, s.At.Y + 2*back.Y}}
+		p.Dir, p.NextDir = s.Dir, s.Dir
+		p.Alive, p.Waiting = true, false
+		p.Score = 0
+	}
+	a.food = nil
+	a.spawnFood()
+}
+
+func (a *arena) Step() {
+	a.frame++
+	switch a.phase {
+	case arenaWaiting:
+		if len(a.players) >= arenaMinPlayers {
+			a.startCountdown()
+		}
+	case arenaCountdown:
+		a.timer--
+		switch {
+		case len(a.players) < arenaMinPlayers:
+			a.clearBoard()
+		case a.timer <= 0:
+			a.phase = arenaRunning
+		}
+	case arenaRunning:
+		a.move()
+		var alive []*arenaPlayer
+		for _, p := range a.players {
+			if p.Alive {
+				alive = append(alive, p)
+			}
+		}
+		if len(alive) <= 1 {
+			a.phase, a.timer, a.winner = arenaOver, resultTicks, ""
+			if len(alive) == 1 {
+				alive[0].Wins++
+				a.winner = alive[0].Name
+			}
+		}
+	case arenaOver:
+		a.timer--
+		if a.timer <= 0 {
+			a.clearBoard()
+			if len(a.players) >= arenaMinPlayers {
+				a.startCountdown()
+			}
+		}
+	}
+}
+
+// clearBoard takes every snake and the food off to wait for players
+func (a *arena) clearBoard() {
+	a.phase = arenaWaiting
+	for _, p := range a.players {
+		p.Body, p.Alive = nil, false
+	}
+	a.food = nil
+}
+
+func (a *arena) startCountdown() {
+	a.phase, a.timer = arenaCountdown, countdownTicks
+	a.placeSnakes()
+}
+
+// move steps every snake at once. A snake dies running into a wall or
+// any body, its own included; two heads meeting, or swapping places, kill
+// both snakes.
+func (a *arena) move() {
+	food := make(map[Point]bool)
+	for _, f := range a.food {
+		food[f] = true
+	}
+
+	// Where everyone ends up before anything is judged
+	bodies := make(map[*arenaPlayer][]Point)
+	heads := make(map[Point]int)
+	occupied := make(map[Point]bool)
+	for _, p := range a.players {
+		if !p.Alive {
+			continue
+		}
+		p.Dir = p.NextDir
+		head := p.Body[0]
+		switch p.Dir {
+		case DirUp:
+			head.Y--
+		case DirDown:
+			head.Y++
+		case DirLeft:
+			head.X--
+		case DirRight:
+			head.X++
+		}
+		body := append([]Point{head}, p.Body...)
+		if !food[head] {
+			body = body[:len(body)-1]
+		}
+		bodies[p] = body
+		heads[head]++
+		for _, c := range body[1:] {
+			occupied[c] = true
+		}
+	}
+
+	for p, body := range bodies {
+		head := body[0]
+		if head.X < 0 || head.X >= arenaW || head.Y < 0 || head.Y >= arenaH || occupied[head] || heads[head] > 1 {
+			// The board is cleared of the snake at once
+			p.Alive = false
+			p.Body = nil
+			continue
+		}
+		p.Body = body
+		if food[head] {
+			p.Score += 10
+			delete(food, head)
+		}
+	}
+
+	a.food = a.food[:0]
+	for f := range food {
+		a.food = append(a.food, f)
+	}
+	a.spawnFood()
+}
+
+// spawnFood tops the board up to one piece of food per two snakes still
+// alive, and at least one
+func (a *arena) spawnFood() {
+	alive := 0
+	taken := make(map[Point]bool)
+	for _, p := range a.players {
+		if p.Alive {
+			alive++
+		}
+		for _, c := range p.Body {
+			taken[c] = true
+		}
+	}
+	for _, f := range a.food {
+		taken[f] = true
+	}
+	for len(a.food) < alive/2+1 && len(taken) < arenaW*arenaH {
+		f := Point{a.rng.Intn(arenaW), a.rng.Intn(arenaH)}
+		if !taken[f] {
+			taken[f] = true
+			a.food = append(a.food, f)
+		}
+	}
+}
+
+func (a *arena) Frame() game.Frame {
+	f := arenaFrame{
+		Phase:  a.phase,
+		Timer:  a.timer,
+		Food:   append([]Point(nil), a.food...),
+		Winner: a.winner,
+		Anim:   (a.frame / 2) % 4,
+	}
+	for _, p := range a.players {
+		c := *p
+		c.Body = append([]Point(nil), p.Body...)
+		f.Players = append(f.Players, c)
+	}
+	return f
+}
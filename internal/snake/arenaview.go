@@ -0,0 +1,140 @@
+package snake
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// arenaColors tell the snakes apart, in the order players join
+var arenaColors = [arenaMaxPlayers]lipgloss.Color{
+	"#00ffcc", "#f0e040", "#ff8c42", "#b388ff",
+	"#7cfc00", "#4aa8ff", "#ff4040", "#e0e0e0",
+}
+
+// arenaFrame is a copy of the arena after a step
+type arenaFrame struct {
+	Phase   string
+	Timer   int
+	Players []arenaPlayer
+	Food    []Point
+	Winner  string
+	Anim    int
+}
+
+func (f arenaFrame) player(pid string) *arenaPlayer {
+	for i := range f.Players {
+		if f.Players[i].ID == pid {
+			return &f.Players[i]
+		}
+	}
+	return nil
+}
+
+func (f arenaFrame) Render(pid string, width, height int) string {
+	cells := make(map[Point]string)
+	for _, p := range f.Players {
+		color := lipgloss.NewStyle().Foreground(arenaColors[p.Color])
+		for i, c := range p.Body {
+			if i == 0 {
+				cells[c] = color.Bold(true).Render("██")
+			} else {
+				cells[c] = color.Render("▓▓")
+			}
+		}
+	}
+	for _, c := range f.Food {
+		cells[c] = foodCell(f.Anim)
+	}
+
+	var board strings.Builder
+	for y := 0; y < arenaH; y++ {
+		for x := 0; x < arenaW; x++ {
+			if cell, ok := cells[Point{x, y}]; ok {
+				board.WriteString(cell)
+			} else {
+				board.WriteString(emptyCell())
+			}
+		}
+		if y < arenaH-1 {
+			board.WriteString("\n")
+		}
+	}
+	boardRendered := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(colorBorder).
+		Render(board.String())
+
+	title := lipgloss.NewStyle().Foreground(lipgloss.Color("#c084fc")).Bold(true).Render("S N A K E   A R E N A")
+	inner := lipgloss.JoinVertical(lipgloss.Center,
+		title,
+		f.renderPlayers(pid, arenaW*2),
+		boardRendered,
+		f.renderStatus(pid),
+	)
+	return outerBox().Render(inner)
+}
+
+// renderPlayers lists everyone who has joined with their colour, score
+// and wins, wrapped to the board's width
+func (f arenaFrame) renderPlayers(pid string, width int) string {
+	if len(f.Players) == 0 {
+		return dimSty.Render("No one has joined yet")
+	}
+	var entries []string
+	for _, p := range f.Players {
+		name := p.Name
+		if p.ID == pid {
+			name += " (you)"
+		}
+		mark := lipgloss.NewStyle().Foreground(arenaColors[p.Color]).Render("●")
+		if !p.Alive && f.Phase == arenaRunning {
+			mark = deadSty.Render("✕")
+		}
+		wins := fmt.Sprintf("(%d wins)", p.Wins)
+		if p.Wins == 1 {
+			wins = "(1 win)"
+		}
+		entries = append(entries, fmt.Sprintf("%s %s %s %s",
+			mark, name, scoreSty.Render(fmt.Sprint(p.Score)), dimSty.Render(wins)))
+	}
+	return lipgloss.NewStyle().Width(width).Align(lipgloss.Center).Render(strings.Join(entries, "   "))
+}
+
+// renderStatus says what the arena is waiting for, or how the round went
+func (f arenaFrame) renderStatus(pid string) string {
+	me := f.player(pid)
+	switch f.Phase {
+	case arenaWaiting:
+		return pauseSty.Render(fmt.Sprintf("Waiting for players: %d/%d joined, %d needed to start",
+			len(f.Players), arenaMaxPlayers, arenaMinPlayers))
+	case arenaCountdown:
+		secs := (f.Timer*int(arenaTick.Milliseconds()) + 999) / 1000
+		return pauseSty.Render(fmt.Sprintf("Round starts in %d...", max(secs, 1)))
+	case arenaOver:
+		if f.Winner == "" {
+			return deadSty.Render("  ✕  Everyone crashed: no winner this round")
+		}
+		return scoreSty.Render("  ★  " + f.Winner + " wins the round!")
+	}
+	switch {
+	case me == nil:
+		return helpSty.Render("Watching")
+	case me.Waiting:
+		return helpSty.Render("You'll play from the next round")
+	case !me.Alive:
+		return deadSty.Render("  ✕  You crashed! Watch the rest of the round")
+	}
+	return ""
+}
+
+func (f arenaFrame) Help(pid string) string {
+	if f.player(pid) == nil {
+		if len(f.Players) >= arenaMaxPlayers {
+			return "The arena is full • q leave"
+		}
+		return "enter join • q leave"
+	}
+	return "arrows/wasd/hjkl steer • x stand down • q leave"
+}
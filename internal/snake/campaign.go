@@ -0,0 +1,240 @@
+package snake
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// campaignMaps are the built-in levels, played in order. Each one opens up
+// once the one before has reached its target.
+var campaignMaps = []string{
+	`name: Pillars
+target: 100
+wrap: no
+..............................
+..............................
+..............................
+..............................
+......##..............##......
+......##..............##......
+..............................
+..............................
+..............................
+..............##..............
+....@.........##..............
+..............................
+..............................
+..............................
+......##..............##......
+......##..............##......
+..............................
+..............................
+..............................
+..............................
+`,
+	`name: Open Box
+target: 150
+wrap: yes
+############......############
+#............................#
+#............................#
+#............................#
+#............................#
+#....@.......................#
+#............................#
+#............................#
+..............................
+..............................
+..............................
+..............................
+#............................#
+#............................#
+#............................#
+#............................#
+#............................#
+#............................#
+#............................#
+############......############
+`,
+	`name: Crossroads
+target: 200
+wrap: no
+..............................
+..............................
+..............................
+...............#..............
+.....@.........#..............
+...............#..............
+...............#..............
+...............#..............
+..............................
+..............................
+......#######....#######......
+..............................
+..............................
+...............#..............
+...............#..............
+...............#..............
+...............#..............
+..............................
+..............................
+..............................
+`,
+	`name: Three Rooms
+target: 200
+wrap: no
+..........#........#..........
+..........#........#..........
+..........#........#..........
+........a.#......b.#..........
+..........#........#..........
+..........#........#..........
+..........#........#..........
+..........#........#..........
+..........#........#..........
+..........#........#..........
+.....@....#........#.......c..
+..........#........#..........
+..........#........#..........
+..........#........#..........
+..........#........#..........
+..........#........#..........
+..c.......#.a......#.b........
+..........#........#..........
+..........#........#..........
+..........#........#..........
+`,
+	`name: Zigzag
+target: 250
+wrap: no
+..............................
+..............................
+.....@........................
+..............................
+######################........
+..............................
+..............................
+..............................
+........######################
+..............................
+..............................
+..............................
+######################........
+..............................
+..............................
+..............................
+........######################
+..............................
+..............................
+..............................
+`,
+	`name: Labyrinth
+target: 300
+wrap: yes
+..............................
+..............a...............
+..............................
+..............................
+....###................###....
+..............................
+..............#...............
+.......#......#.......#.......
+.......#..............#.......
+.......#..............#.......
+.......#...@..........#.......
+.......#..............#.......
+.......#.......#......#.......
+...............#..............
+..............................
+....###................###....
+..............................
+..............................
+...............a..............
+..............................
+`,
+}
+
+// campaign is campaignMaps, parsed
+var campaign = func() []Level {
+	levels := make([]Level, len(campaignMaps))
+	for i, m := range campaignMaps {
+		l, err := ParseLevel(m)
+		if err != nil {
+			panic("snake: campaign level " + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+		levels[i] = l
+	}
+	return levels
+}()
+
+// campaignBoard is the leaderboard of the i'th campaign level, from 0. A
+// player's best on it also says whether they have cleared it.
+func campaignBoard(i int) string {
+	return "snake-level-" + strconv.Itoa(i+1)
+}
+
+// campaignTitle names the i'th campaign level, e.g. "Level 2 · Open Box"
+func campaignTitle(i int) string {
+	return fmt.Sprintf("Level %d · %s", i+1, campaign[i].Name)
+}
+
+// campaignCleared counts the campaign levels whose target the player has
+// reached
+func (m Model) campaignCleared() int {
+	n := 0
+	for i, l := range campaign {
+		if m.highscore[campaignBoard(i)] >= l.Target {
+			n++
+		}
+	}
+	return n
+}
+
+func (m Model) renderCampaign() string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(lipgloss.NewStyle().Foreground(campaignColor).Bold(true).Render("  CAMPAIGN"))
+	sb.WriteString("\n")
+	sb.WriteString(dimSty.Render("  ─────────────────"))
+	sb.WriteString("\n")
+
+	for i, l := range campaign {
+		best := m.highscore[campaignBoard(i)]
+		line := fmt.Sprintf("%d. %-12s target %06d", i+1, l.Name, l.Target)
+		switch {
+		case !m.unlocked(i):
+			line = fmt.Sprintf("%d. %-12s locked", i+1, l.Name)
+		case best >= l.Target:
+			line += fmt.Sprintf("   best %06d ✓", best)
+		case best > 0:
+			line += fmt.Sprintf("   best %06d", best)
+		}
+
+		style := lipgloss.NewStyle().Foreground(colorDim)
+		if m.unlocked(i) {
+			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#aaaaee"))
+		}
+		if i == m.listSel {
+			sb.WriteString(lipgloss.NewStyle().Foreground(campaignColor).Bold(true).Render("  ▶ " + line))
+		} else {
+			sb.WriteString(style.Render("    " + line))
+		}
+		sb.WriteString("\n")
+	}
+
+	if lb := m.renderLeaderboard(campaignBoard(m.listSel), campaignTitle(m.listSel), campaignColor, -1); lb != "" {
+		sb.WriteString("\n")
+		sb.WriteString(lb)
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(keySty.Render("  ↑ ↓ to choose   ENTER to play   ESC back"))
+	sb.WriteString("\n\n")
+	sb.WriteString(helpSty.Render("  Reach a level's target to open the next one"))
+	sb.WriteString("\n")
+	return outerBox().Render(sb.String())
+}
@@ -0,0 +1,308 @@
+package snake
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxLevelName is how long a level's name may be; it is the key the level
+// is saved under
+const maxLevelName = 24
+
+// maxPortals is how many pairs of portals a level can have, one per letter
+const maxPortals = 26
+
+// editor draws a level cell by cell
+type editor struct {
+	level Level
+	name  string // What the level is saved as, or "" if it never has been
+	x, y  int    // Cursor
+
+	portal *Point // First end of a portal, waiting for the other
+	naming bool   // Typing a name into input
+	input  string
+
+	msg     string
+	dirty   bool   // Changed since it was last saved
+	confirm string // Key that must be pressed again to go through with it
+	saving  string // Name a save is under way for
+}
+
+// blankLevel is an empty classic-sized board to start drawing on
+func blankLevel() Level {
+	l := Classic()
+	l.Name = ""
+	return l
+}
+
+func newEditor(l Level, name string) *editor {
+	return &editor{level: l, name: name, x: l.Start.X, y: l.Start.Y}
+}
+
+// clone copies l so it can be drawn on without changing the original
+func (l Level) clone() Level {
+	c := l
+	c.Walls = make(map[Point]bool, len(l.Walls))
+	for p := range l.Walls {
+		c.Walls[p] = true
+	}
+	c.Portals = make(map[Point]Point, len(l.Portals))
+	for p, q := range l.Portals {
+		c.Portals[p] = q
+	}
+	return c
+}
+
+// clear empties p of its wall or portal; a portal's other end goes too
+func (e *editor) clear(p Point) {
+	delete(e.level.Walls, p)
+	if q, ok := e.level.Portals[p]; ok {
+		delete(e.level.Portals, p)
+		delete(e.level.Portals, q)
+	}
+	if e.portal != nil && *e.portal == p {
+		e.portal = nil
+	}
+}
+
+// saved hears back from a save the editor started
+func (e *editor) saved(err error) {
+	if e.saving == "" {
+		return
+	}
+	if err != nil {
+		e.msg = "Couldn't save: " + err.Error()
+	} else {
+		e.name, e.dirty, e.msg = e.saving, false, "Saved "+e.saving
+	}
+	e.saving = ""
+}
+
+// nameChar reports whether c can be part of a level's name
+func nameChar(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == ' ' || c == '-' || c == '_'
+}
+
+// editorKey handles StateEditor
+func (m *Model) editorKey(k string) tea.Cmd {
+	e := m.editor
+	if e.naming {
+		switch k {
+		case "enter":
+			if name := strings.TrimSpace(e.input); name != "" && name != e.level.Name {
+				e.level.Name, e.dirty = name, true
+			}
+			e.naming = false
+		case "esc":
+			e.naming = false
+		case "backspace":
+			if e.input != "" {
+				e.input = e.input[:len(e.input)-1]
+			}
+		default:
+			if r := []rune(k); len(r) == 1 && nameChar(r[0]) && len(e.input) < maxLevelName {
+				e.input += k
+			}
+		}
+		return nil
+	}
+
+	confirm := e.confirm
+	e.confirm, e.msg = "", ""
+	p := Point{e.x, e.y}
+	switch k {
+	case "up", "k":
+		e.y = max(e.y-1, 0)
+	case "down", "j":
+		e.y = min(e.y+1, e.level.H-1)
+	case "left", "h":
+		e.x = max(e.x-1, 0)
+	case "right", "l":
+		e.x = min(e.x+1, e.level.W-1)
+
+	case " ", "enter":
+		if p == e.level.Start {
+			e.msg = "The snake starts here"
+			return nil
+		}
+		wall := !e.level.Walls[p]
+		e.clear(p)
+		if wall {
+			e.level.Walls[p] = true
+		}
+		e.dirty = true
+	case "x", "backspace", "delete":
+		e.clear(p)
+		e.dirty = true
+	case "p":
+		switch {
+		case p == e.level.Start:
+			e.msg = "The snake starts here"
+		case e.portal != nil && *e.portal == p:
+			e.portal = nil
+		case e.portal != nil:
+			from := *e.portal
+			e.clear(from)
+			e.clear(p)
+			e.level.Portals[from], e.level.Portals[p] = p, from
+			e.dirty = true
+		case len(e.level.Portals)/2 >= maxPortals:
+			e.msg = fmt.Sprintf("A level can have at most %d portals", maxPortals)
+		default:
+			e.clear(p)
+			e.portal = &p
+			e.msg = "Now place the other end with P"
+		}
+	case "s":
+		e.clear(p)
+		e.level.Start = p
+		e.dirty = true
+	case "w":
+		e.level.Wrap = !e.level.Wrap
+		e.dirty = true
+	case "n":
+		e.naming, e.input = true, e.level.Name
+
+	case "t":
+		if err := e.level.check(); err != nil {
+			e.msg = "Can't play it: " + err.Error()
+			return nil
+		}
+		m.playCustom(e.level.clone())
+	case "ctrl+s":
+		return m.saveLevel(confirm == k)
+	case "q", "esc":
+		if e.dirty && confirm != "q" {
+			e.confirm, e.msg = "q", "Unsaved changes. Press Q again to throw them away"
+			return nil
+		}
+		m.editor = nil
+		m.State = StateLevels
+		return m.loadLevelsCmd()
+	}
+	return nil
+}
+
+// saveLevel saves the level being drawn under its name. Saving over another
+// of the player's levels has to be confirmed.
+func (m *Model) saveLevel(confirmed bool) tea.Cmd {
+	e := m.editor
+	name, old := e.level.Name, e.name
+	if err := e.level.check(); err != nil {
+		e.msg = "Can't save: " + err.Error()
+		return nil
+	}
+	switch _, taken := m.levels[name]; {
+	case m.library == nil:
+		e.msg = "Levels can't be kept on this server"
+		return nil
+	case name == "":
+		e.msg = "Name the level first with N"
+		return nil
+	case taken && name != old && !confirmed:
+		e.confirm, e.msg = "ctrl+s", "You already have a level called "+name+". Press CTRL+S again to replace it"
+		return nil
+	}
+
+	lib, text := m.library, e.level.String()
+	e.saving, e.msg = name, "Saving..."
+	return libraryCmd(lib, func() error {
+		if err := lib.Save(levelKind, name, text); err != nil {
+			return err
+		}
+		if old != "" && old != name {
+			// It was renamed
+			return lib.Delete(levelKind, old)
+		}
+		return nil
+	}, "Saved "+name)
+}
+
+func (m Model) renderEditor() string {
+	e := m.editor
+	body := make(map[Point]int)
+	for i, p := range e.level.body() {
+		body[p] = i
+	}
+	letters := e.level.portalLetters()
+	cursor := lipgloss.NewStyle().Foreground(lipgloss.Color("#000000")).Background(colorScore)
+
+	var board strings.Builder
+	for y := 0; y < e.level.H; y++ {
+		for x := 0; x < e.level.W; x++ {
+			p := Point{x, y}
+			idx, isBody := body[p]
+			switch {
+			case x == e.x && y == e.y:
+				board.WriteString(cursor.Render(e.glyph(p, letters)))
+			case e.portal != nil && *e.portal == p:
+				board.WriteString(portalCell('?'))
+			case isBody && e.level.open(p):
+				board.WriteString(snakeCell(idx, true))
+			default:
+				board.WriteString(levelCell(e.level, letters, p))
+			}
+		}
+		if y < e.level.H-1 {
+			board.WriteString("\n")
+		}
+	}
+
+	name := e.level.Name
+	if name == "" {
+		name = "untitled"
+	}
+	wrap := "no"
+	if e.level.Wrap {
+		wrap = "yes"
+	}
+	header := fmt.Sprintf(" %s  %s    %s  %s    %s  %s",
+		dimSty.Render("LEVEL"), lipgloss.NewStyle().Foreground(levelsColor).Bold(true).Render(name),
+		dimSty.Render("SIZE"), scoreSty.Render(fmt.Sprintf("%d×%d", e.level.W, e.level.H)),
+		dimSty.Render("WRAP"), scoreSty.Render(wrap),
+	)
+	if e.dirty {
+		header += dimSty.Render("    modified")
+	}
+
+	var status string
+	switch {
+	case e.naming:
+		status = keySty.Render("  Name: ") + scoreSty.Render(e.input+"▏") + helpSty.Render("   ENTER done   ESC cancel")
+	case e.msg != "":
+		status = pauseSty.Render("  " + e.msg)
+	default:
+		status = helpSty.Render(fmt.Sprintf("  Cursor %d,%d", e.x+1, e.y+1))
+	}
+
+	boardRendered := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(colorBorder).
+		Render(board.String())
+
+	return outerBox().Render(lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		boardRendered,
+		status,
+		helpSty.Render("  Arrows/HJKL: move   SPACE: wall   P: portal   S: start   X: clear"),
+		helpSty.Render("  W: wrap   N: name   T: try it   CTRL+S: save   Q: back"),
+	))
+}
+
+// glyph is the text of the cell at p, for drawing under the cursor
+func (e *editor) glyph(p Point, letters map[Point]byte) string {
+	switch letter, portal := letters[p]; {
+	case p == e.level.Start:
+		return "@@"
+	case e.level.Walls[p]:
+		return "▓▓"
+	case portal:
+		return " " + string(letter)
+	case e.portal != nil && *e.portal == p:
+		return " ?"
+	}
+	return "··"
+}
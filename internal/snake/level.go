@@ -0,0 +1,256 @@
+package snake
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Level is a board to play on: its size, its walls and portals, whether
+// its edges wrap round, and where the snake starts. Its head starts on
+// Start heading right, with its body in the two cells to the left.
+type Level struct {
+	Name    string
+	Target  int // Score that clears it in the campaign; 0 = none
+	W, H    int
+	Wrap    bool
+	Walls   map[Point]bool
+	Portals map[Point]Point // Each end to the other
+	Start   Point
+}
+
+// Limits on the size of a level's board, and the fewest open cells it must
+// have beyond the ones the snake starts on, so there is room for food
+const (
+	minLevelW, maxLevelW = 10, 60
+	minLevelH, maxLevelH = 6, 40
+	minFreeCells         = 10
+)
+
+// Classic is the empty board with deadly edges that the daily challenge is
+// played on, and the difficulties too when the terminal is classic-sized
+func Classic() Level {
+	return openBoard("Classic", boardW, boardH)
+}
+
+// openBoard is an empty w×h board with deadly edges, started in the middle
+func openBoard(name string, w, h int) Level {
+	return Level{
+		Name:    name,
+		W:       w,
+		H:       h,
+		Walls:   make(map[Point]bool),
+		Portals: make(map[Point]Point),
+		Start:   Point{w/2 + 1, h / 2},
+	}
+}
+
+// open reports whether p is on the board and clear of walls and portals
+func (l Level) open(p Point) bool {
+	if p.X < 0 || p.X >= l.W || p.Y < 0 || p.Y >= l.H {
+		return false
+	}
+	_, portal := l.Portals[p]
+	return !l.Walls[p] && !portal
+}
+
+// next is where a head at p moving in dir ends up: round the edge if the
+// level wraps, and out of the other end if it lands on a portal. ok is false
+// if it runs off the board.
+func (l Level) next(p Point, dir Direction) (q Point, ok bool) {
+	switch dir {
+	case DirUp:
+		p.Y--
+	case DirDown:
+		p.Y++
+	case DirLeft:
+		p.X--
+	case DirRight:
+		p.X++
+	}
+	if l.Wrap {
+		p.X = (p.X + l.W) % l.W
+		p.Y = (p.Y + l.H) % l.H
+	}
+	if p.X < 0 || p.X >= l.W || p.Y < 0 || p.Y >= l.H {
+		return p, false
+	}
+	if exit, ok := l.Portals[p]; ok {
+		return exit, true
+	}
+	return p, true
+}
+
+// body is where the snake starts: its head on Start and two cells behind
+func (l Level) body() []Point {
+	return []Point{l.Start, {l.Start.X - 1, l.Start.Y}, {l.Start.X - 2, l.Start.Y}}
+}
+
+// check reports what is wrong with a level that can't be played
+func (l Level) check() error {
+	if l.W < minLevelW || l.W > maxLevelW || l.H < minLevelH || l.H > maxLevelH {
+		return fmt.Errorf("level must be %d to %d wide and %d to %d high", minLevelW, maxLevelW, minLevelH, maxLevelH)
+	}
+	for _, p := range l.body() {
+		if !l.open(p) {
+			return fmt.Errorf("the snake needs three open cells from the start to the left")
+		}
+	}
+	free := -len(l.body())
+	for y := 0; y < l.H; y++ {
+		for x := 0; x < l.W; x++ {
+			if l.open(Point{x, y}) {
+				free++
+			}
+		}
+	}
+	if free < minFreeCells {
+		return fmt.Errorf("level needs at least %d open cells besides the snake's, has %d", minFreeCells, free)
+	}
+	return nil
+}
+
+// A level is written as a text map: a few "key: value" lines, for its name,
+// target and whether it wraps, then the board a row per line, where
+//
+//	#    is a wall
+//	.    is open floor
+//	@    is where the snake's head starts
+//	a-z  are portals: the two cells with the same letter lead to each other
+//
+// For example:
+//
+//	name: Pillars
+//	target: 100
+//	wrap: no
+//	..........
+//	..#....#..
+//	.@........
+//	..#....#..
+func ParseLevel(text string) (Level, error) {
+	l := Level{Walls: make(map[Point]bool), Portals: make(map[Point]Point)}
+	var rows []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \r")
+		if key, value, ok := strings.Cut(line, ":"); ok && rows == nil {
+			value = strings.TrimSpace(value)
+			switch strings.TrimSpace(key) {
+			case "name":
+				l.Name = value
+			case "target":
+				t, err := strconv.Atoi(value)
+				if err != nil || t < 0 {
+					return Level{}, fmt.Errorf("bad target %q", value)
+				}
+				l.Target = t
+			case "wrap":
+				l.Wrap = value == "yes"
+			default:
+				return Level{}, fmt.Errorf("unknown level setting %q", key)
+			}
+			continue
+		}
+		if line != "" {
+			rows = append(rows, line)
+		}
+	}
+	if len(rows) == 0 {
+		return Level{}, fmt.Errorf("level has no board")
+	}
+	if len(rows) > maxLevelH || len(rows[0]) > maxLevelW {
+		return Level{}, fmt.Errorf("level is bigger than %d×%d", maxLevelW, maxLevelH)
+	}
+
+	l.W, l.H = len(rows[0]), len(rows)
+	starts := 0
+	ends := make(map[byte][]Point)
+	for y, row := range rows {
+		if len(row) != l.W {
+			return Level{}, fmt.Errorf("row %d is %d wide, not %d", y+1, len(row), l.W)
+		}
+		for x := 0; x < len(row); x++ {
+			p := Point{x, y}
+			switch c := row[x]; {
+			case c == '#':
+				l.Walls[p] = true
+			case c == '@':
+				l.Start = p
+				starts++
+			case c >= 'a' && c <= 'z':
+				ends[c] = append(ends[c], p)
+			case c != '.':
+				return Level{}, fmt.Errorf("unknown cell %q on row %d", c, y+1)
+			}
+		}
+	}
+	if starts != 1 {
+		return Level{}, fmt.Errorf("level needs exactly one @ start, not %d", starts)
+	}
+	for c, ps := range ends {
+		if len(ps) != 2 {
+			return Level{}, fmt.Errorf("portal %c needs two ends, not %d", c, len(ps))
+		}
+		l.Portals[ps[0]], l.Portals[ps[1]] = ps[1], ps[0]
+	}
+	return l, l.check()
+}
+
+// String writes l as a text map for ParseLevel
+func (l Level) String() string {
+	var sb strings.Builder
+	if l.Name != "" {
+		fmt.Fprintf(&sb, "name: %s\n", l.Name)
+	}
+	if l.Target > 0 {
+		fmt.Fprintf(&sb, "target: %d\n", l.Target)
+	}
+	wrap := "no"
+	if l.Wrap {
+		wrap = "yes"
+	}
+	fmt.Fprintf(&sb, "wrap: %s\n", wrap)
+
+	letters := l.portalLetters()
+	for y := 0; y < l.H; y++ {
+		for x := 0; x < l.W; x++ {
+			p := Point{x, y}
+			switch letter, portal := letters[p]; {
+			case p == l.Start:
+				sb.WriteByte('@')
+			case l.Walls[p]:
+				sb.WriteByte('#')
+			case portal:
+				sb.WriteByte(letter)
+			default:
+				sb.WriteByte('.')
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// portalLetters names the portals in the order they are met, row by row,
+// so the same level always gets the same letters. There are at most 26.
+func (l Level) portalLetters() map[Point]byte {
+	ends := make([]Point, 0, len(l.Portals))
+	for p := range l.Portals {
+		ends = append(ends, p)
+	}
+	sort.Slice(ends, func(i, j int) bool {
+		if ends[i].Y != ends[j].Y {
+			return ends[i].Y < ends[j].Y
+		}
+		return ends[i].X < ends[j].X
+	})
+	letters := make(map[Point]byte)
+	next := byte('a')
+	for _, p := range ends {
+		if _, ok := letters[p]; !ok {
+			letters[p], letters[l.Portals[p]] = next, next
+			next++
+		}
+	}
+	return letters
+}
@@ -0,0 +1,112 @@
+package snake
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCampaignLevelsRoundTrip(t *testing.T) {
+	for i, text := range campaignMaps {
+		l, err := ParseLevel(text)
+		if err != nil {
+			t.Fatalf("campaign map %d: %v", i, err)
+		}
+		if got := l.String(); got != text {
+			t.Errorf("%s written back as\n%s\nwant\n%s", l.Name, got, text)
+		}
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	l, err := ParseLevel(`name: Tunnel
+target: 40
+wrap: yes
+##########
+#........#
+#..@.....a
+#........#
+a........#
+##########
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Name != "Tunnel" || l.Target != 40 || !l.Wrap || l.W != 10 || l.H != 6 || l.Start != (Point{3, 2}) {
+		t.Errorf("parsed %q target %d wrap %v %d×%d start %v", l.Name, l.Target, l.Wrap, l.W, l.H, l.Start)
+	}
+	if !l.Walls[Point{0, 0}] || l.Walls[Point{1, 1}] {
+		t.Errorf("walls = %v", l.Walls)
+	}
+	if l.Portals[Point{9, 2}] != (Point{0, 4}) || l.Portals[Point{0, 4}] != (Point{9, 2}) {
+		t.Errorf("portals = %v", l.Portals)
+	}
+	if p, ok := l.next(Point{8, 2}, DirRight); !ok || p != (Point{0, 4}) {
+		t.Errorf("stepping into portal a lands on %v, %v", p, ok)
+	}
+}
+
+// rows is a w×h board of open floor with the start in the middle
+func rows(w, h int) string {
+	var sb strings.Builder
+	for y := 0; y < h; y++ {
+		row := strings.Repeat(".", w)
+		if y == h/2 {
+			row = row[:w/2] + "@" + row[w/2+1:]
+		}
+		sb.WriteString(row + "\n")
+	}
+	return sb.String()
+}
+
+func TestParseLevelRejects(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{"no board", "name: Empty\n", "no board"},
+		{"unknown setting", "speed: 3\n" + rows(10, 6), "unknown level setting"},
+		{"bad target", "target: lots\n" + rows(10, 6), "bad target"},
+		{"too narrow", rows(9, 6), "10 to 60 wide"},
+		{"too short", rows(10, 5), "6 to 40 high"},
+		{"too big", rows(61, 6), "bigger than"},
+		{"ragged", rows(10, 6) + "...\n", "row 7 is 3 wide"},
+		{"unknown cell", strings.Replace(rows(10, 6), ".", "X", 1), "unknown cell"},
+		{"no start", strings.Replace(rows(10, 6), "@", ".", 1), "exactly one @"},
+		{"two starts", strings.Replace(rows(10, 6), ".", "@", 1), "exactly one @"},
+		{"lone portal", strings.Replace(rows(10, 6), ".", "q", 1), "portal q needs two ends"},
+		{"start on the edge", "@" + strings.Replace(rows(10, 6), "@", ".", 1)[1:], "three open cells"},
+		{"start against a wall", strings.Replace(rows(10, 6), ".@", "#@", 1), "three open cells"},
+		{"no room for food",
+			"##########\n##########\n######...@\n##########\n##########\n##########\n",
+			"at least 10 open cells"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseLevel(tt.text)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseLevel = %v, want an error about %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpawnFoodOnFullBoard(t *testing.T) {
+	m := InitialModel()
+	m.rng = rand.New(rand.NewSource(1))
+	m.level = openBoard("", 10, 6)
+	m.snake = nil
+	for y := 0; y < m.level.H; y++ {
+		for x := 0; x < m.level.W; x++ {
+			if p := (Point{x, y}); p != (Point{7, 4}) {
+				m.snake = append(m.snake, p)
+			}
+		}
+	}
+	if p, ok := m.spawnFood(); !ok || p != (Point{7, 4}) {
+		t.Errorf("spawnFood with one cell free = %v, %v, want (7,4)", p, ok)
+	}
+	m.snake = append(m.snake, Point{7, 4})
+	if p, ok := m.spawnFood(); ok {
+		t.Errorf("spawnFood on a full board = %v, want none", p)
+	}
+}
@@ -0,0 +1,164 @@
+package snake
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aminshahid573/termplay/internal/game"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// levelKind is what the player's own levels are kept as in their library,
+// each as its text map under its name
+const levelKind = "snake-level"
+
+// parseLevels reads the player's saved levels, leaving out any that no
+// longer parse
+func parseLevels(saved map[string]string) map[string]Level {
+	levels := make(map[string]Level, len(saved))
+	for name, text := range saved {
+		if l, err := ParseLevel(text); err == nil {
+			l.Name = name
+			levels[name] = l
+		}
+	}
+	return levels
+}
+
+// levelNames lists the player's own levels by name
+func (m Model) levelNames() []string {
+	names := make([]string, 0, len(m.levels))
+	for name := range m.levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadLevelsCmd fetches the player's own levels
+func (m Model) loadLevelsCmd() tea.Cmd {
+	if m.library == nil {
+		return nil
+	}
+	return libraryCmd(m.library, nil, "")
+}
+
+// libraryCmd makes change to the library, if there is one, and then fetches
+// the player's levels again. note says what the change was.
+func libraryCmd(lib game.Library, change func() error, note string) tea.Cmd {
+	return func() tea.Msg {
+		if change != nil {
+			if err := change(); err != nil {
+				return levelsMsg{err: err}
+			}
+		}
+		levels, err := lib.Load(levelKind)
+		return levelsMsg{levels: levels, note: note, err: err}
+	}
+}
+
+// openEditor starts drawing l, which was saved under name, or is new if
+// name is ""
+func (m *Model) openEditor(l Level, name string) {
+	m.editor = newEditor(l.clone(), name)
+	m.State = StateEditor
+}
+
+// levelsKey handles StateLevels, where the first entry draws a new level
+// and the rest are the player's levels
+func (m *Model) levelsKey(k string) tea.Cmd {
+	names := m.levelNames()
+	var picked string
+	if m.listSel > 0 {
+		picked = names[m.listSel-1]
+	}
+	deleting := m.deleting
+	m.deleting = false
+
+	switch k {
+	case "up", "w", "k":
+		m.listSel = max(m.listSel-1, 0)
+	case "down", "s", "j":
+		m.listSel = min(m.listSel+1, len(names))
+	case "enter", " ":
+		if picked == "" {
+			m.openEditor(blankLevel(), "")
+			return nil
+		}
+		m.playCustom(m.levels[picked])
+	case "n":
+		m.openEditor(blankLevel(), "")
+	case "e":
+		if picked != "" {
+			m.openEditor(m.levels[picked], picked)
+		}
+	case "d", "x":
+		if picked == "" {
+			return nil
+		}
+		if !deleting {
+			m.deleting, m.note = true, "Press D again to delete "+picked
+			return nil
+		}
+		lib := m.library
+		m.listSel = min(m.listSel, len(names)-1)
+		return libraryCmd(lib, func() error { return lib.Delete(levelKind, picked) }, "Deleted "+picked)
+	case "q", "esc":
+		m.State = StateMenu
+	}
+	m.note = ""
+	return nil
+}
+
+func (m Model) renderLevels() string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(lipgloss.NewStyle().Foreground(levelsColor).Bold(true).Render("  MY LEVELS"))
+	sb.WriteString("\n")
+	sb.WriteString(dimSty.Render("  ─────────────────"))
+	sb.WriteString("\n")
+
+	entry := func(i int, line string) {
+		if i == m.listSel {
+			sb.WriteString(lipgloss.NewStyle().Foreground(levelsColor).Bold(true).Render("  ▶ " + line))
+		} else {
+			sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#aaaaee")).Render("    " + line))
+		}
+		sb.WriteString("\n")
+	}
+	entry(0, "+ New level")
+	for i, name := range m.levelNames() {
+		l := m.levels[name]
+		line := fmt.Sprintf("%-24s %d×%d", name, l.W, l.H)
+		if l.Wrap {
+			line += "  wraps"
+		}
+		entry(i+1, line)
+	}
+
+	sb.WriteString("\n")
+	switch {
+	case m.library == nil:
+		sb.WriteString(helpSty.Render("  Levels can't be kept on this server, but you can still draw and try them"))
+		sb.WriteString("\n")
+	case m.levelsErr != nil:
+		sb.WriteString(deadSty.Render("  " + m.levelsErr.Error()))
+		sb.WriteString("\n")
+	case m.levels == nil:
+		sb.WriteString(helpSty.Render("  Loading levels..."))
+		sb.WriteString("\n")
+	case m.note != "":
+		sb.WriteString(pauseSty.Render("  " + m.note))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(keySty.Render("  ↑ ↓ to choose   ENTER to play   ESC back"))
+	sb.WriteString("\n\n")
+	sb.WriteString(helpSty.Render("  N: new level   E: edit   D: delete"))
+	sb.WriteString("\n")
+	return outerBox().Render(sb.String())
+}
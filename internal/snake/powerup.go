@@ -0,0 +1,207 @@
+package snake
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Food is what a piece of food does when it is eaten. Only arcade runs get
+// anything but plain food.
+type Food int
+
+const (
+	FoodPlain  Food = iota
+	FoodSlow        // Slows the snake down for a while
+	FoodDouble      // Doubles the points for food for a while
+	FoodShrink      // Cuts the tail short
+	FoodGhost       // Lets the snake pass through itself for a while
+	foodKinds
+)
+
+var foodNames = [foodKinds]string{"Food", "Slow-mo", "×2 points", "Shrink", "Ghost"}
+var foodColors = [foodKinds]lipgloss.Color{colorFood, "#60a5fa", "#facc15", "#a3e635", "#e2e8f0"}
+
+// How power-ups behave, counted in the snake's steps so that a replay plays
+// them out exactly as they happened
+const (
+	specialOdds = 4  // One piece of food in this many is a power-up
+	specialLife = 50 // Steps a power-up waits to be eaten before it goes plain
+	slowBy      = 3  // Extra ticks per step while slowed
+	shrinkBy    = 4  // Tail cells a shrink cuts off, leaving at least three
+)
+
+// effectSteps is how long each timed power-up lasts
+var effectSteps = [foodKinds]int{FoodSlow: 40, FoodDouble: 50, FoodGhost: 30}
+
+// arcadeSpeeds is how many ticks an arcade step takes from each score up:
+// the snake starts slower than on Normal and ends as fast as on Hard
+var arcadeSpeeds = []struct{ score, every int }{{0, 6}, {50, 5}, {150, 4}, {300, 3}, {500, 2}}
+
+var arcadeColor = lipgloss.Color("#f472b6")
+
+// arcadeBoard is the leaderboard of arcade runs
+const arcadeBoard = "snake-arcade"
+
+// speedTier is which of arcadeSpeeds an arcade run is on at score
+func speedTier(score int) int {
+	tier := 0
+	for i, s := range arcadeSpeeds {
+		if score >= s.score {
+			tier = i
+		}
+	}
+	return tier
+}
+
+// runStats add up a run for the game-over screen
+type runStats struct {
+	ticks   int            // Played, not paused
+	longest int            // Most cells the snake had
+	eaten   [foodKinds]int // Food eaten, by kind
+	under   [foodKinds]int // Steps spent under each timed power-up
+	bonus   int            // Points that came from ×2
+	topTier int            // Fastest arcade speed reached
+}
+
+// moveEvery is how many ticks the snake takes per step just now
+func (m Model) moveEvery() int {
+	switch {
+	case m.watching != nil && m.fast:
+		return 1
+	case !m.arcade:
+		return diffMoveEvery[m.diff]
+	}
+	every := arcadeSpeeds[speedTier(m.score)].every
+	if m.effects[FoodSlow] > 0 {
+		every += slowBy
+	}
+	return every
+}
+
+// placeFood puts down the next piece of food; on an arcade run it is
+// sometimes a power-up. It reports false if there is nowhere left to put it.
+func (m *Model) placeFood() bool {
+	food, ok := m.spawnFood()
+	if !ok {
+		return false
+	}
+	m.food, m.foodKind, m.foodLeft = food, FoodPlain, 0
+	if m.arcade && m.rng.Intn(specialOdds) == 0 {
+		m.foodKind = Food(1 + m.rng.Intn(int(foodKinds)-1))
+		m.foodLeft = specialLife
+	}
+	return true
+}
+
+// wearOff counts a step off every timed power-up and off the power-up
+// waiting on the board
+func (m *Model) wearOff() {
+	for k := range m.effects {
+		if m.effects[k] > 0 {
+			m.effects[k]--
+			m.stats.under[k]++
+		}
+	}
+	if m.foodLeft > 0 {
+		if m.foodLeft--; m.foodLeft == 0 {
+			m.foodKind = FoodPlain
+		}
+	}
+}
+
+// eat scores the food just eaten and sets off what it does
+func (m *Model) eat() {
+	gain := 10
+	if m.effects[FoodDouble] > 0 {
+		gain *= 2
+		m.stats.bonus += gain / 2
+	}
+	m.score += gain
+	m.stats.eaten[m.foodKind]++
+
+	switch m.foodKind {
+	case FoodShrink:
+		m.snake = m.snake[:max(len(m.snake)-shrinkBy, 3)]
+	case FoodSlow, FoodDouble, FoodGhost:
+		m.effects[m.foodKind] = effectSteps[m.foodKind]
+	}
+	if m.arcade {
+		m.stats.topTier = max(m.stats.topTier, speedTier(m.score))
+	}
+}
+
+// specialCell draws a power-up waiting to be eaten; it flickers as it is
+// about to go plain
+func specialCell(kind Food, anim, left int) string {
+	glyphs := [4]string{"◆", "◈", "◇", "◈"}
+	style := lipgloss.NewStyle().Foreground(foodColors[kind]).Bold(true)
+	if left <= 10 && anim%2 == 1 {
+		style = style.Faint(true)
+	}
+	return style.Render(" " + glyphs[anim])
+}
+
+// renderEffects lists the timed power-ups in play with the steps they have
+// left, or is empty if there are none
+func (m Model) renderEffects() string {
+	var parts []string
+	for k, left := range m.effects {
+		if left > 0 {
+			style := lipgloss.NewStyle().Foreground(foodColors[k]).Bold(true)
+			parts = append(parts, style.Render(fmt.Sprintf("%s %d", foodNames[k], left)))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(parts, "   ")
+}
+
+// renderLegend shows which colour of food does what
+func renderLegend() string {
+	var parts []string
+	for k := FoodSlow; k < foodKinds; k++ {
+		style := lipgloss.NewStyle().Foreground(foodColors[k]).Bold(true)
+		parts = append(parts, style.Render("◆ ")+helpSty.Render(foodNames[k]))
+	}
+	return "  " + strings.Join(parts, "  ")
+}
+
+// renderStats breaks the run just played down
+func (m Model) renderStats() string {
+	var sb strings.Builder
+	line := func(label, value string) {
+		sb.WriteString("\n")
+		sb.WriteString(dimSty.Render(fmt.Sprintf("  %-14s", label)))
+		sb.WriteString(scoreSty.Render(value))
+	}
+	sb.WriteString(dimSty.Render("  RUN STATS"))
+	sb.WriteString("\n")
+	sb.WriteString(dimSty.Render("  ─────────────────"))
+
+	secs := m.stats.ticks * int(uiTick.Milliseconds()) / 1000
+	line("Time", fmt.Sprintf("%d:%02d", secs/60, secs%60))
+	line("Steps", fmt.Sprint(m.steps))
+	line("Longest", fmt.Sprint(m.stats.longest))
+	eaten := 0
+	for _, n := range m.stats.eaten {
+		eaten += n
+	}
+	line("Food eaten", fmt.Sprint(eaten))
+	if !m.arcade {
+		return sb.String()
+	}
+
+	line("Top speed", fmt.Sprintf("%d of %d", m.stats.topTier+1, len(arcadeSpeeds)))
+	for k := FoodSlow; k < foodKinds; k++ {
+		value := fmt.Sprintf("%d", m.stats.eaten[k])
+		if effectSteps[k] > 0 {
+			value += fmt.Sprintf(" · %d steps", m.stats.under[k])
+		}
+		line(foodNames[k], value)
+	}
+	line("Bonus points", fmt.Sprintf("+%d", m.stats.bonus))
+	return sb.String()
+}
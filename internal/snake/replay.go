@@ -0,0 +1,103 @@
+package snake
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Turn is a change of direction, taken on the snake's Step'th move
+type Turn struct {
+	Step int
+	Dir  Direction
+}
+
+// Replay is a recorded run. Its seed decides where every piece of food
+// appears and its difficulty, or being an arcade run, how fast the snake
+// goes, so together with the size of its board and the turns the run plays
+// out again exactly.
+type Replay struct {
+	Seed   int64
+	Diff   int
+	Arcade bool
+	W, H   int // Size of the board; 0 for the classic one
+	Turns  []Turn
+}
+
+// dirLetters name the directions in a replay, in Direction order
+const dirLetters = "udlr"
+
+// String encodes r for the leaderboard: the seed, the difficulty, then each
+// turn as its step and direction, e.g. "20261017 1 4u 9l 15d". An arcade
+// run's difficulty is marked with an a, and any board but the classic one
+// follows it, as in "1a@44x28".
+func (r Replay) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d %d", r.Seed, r.Diff)
+	if r.Arcade {
+		sb.WriteByte('a')
+	}
+	if r.W > 0 {
+		fmt.Fprintf(&sb, "@%dx%d", r.W, r.H)
+	}
+	for _, t := range r.Turns {
+		fmt.Fprintf(&sb, " %d%c", t.Step, dirLetters[t.Dir])
+	}
+	return sb.String()
+}
+
+// ParseReplay reads a replay written by Replay.String
+func ParseReplay(s string) (Replay, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return Replay{}, fmt.Errorf("replay has no seed and difficulty")
+	}
+	var r Replay
+	var err error
+	if r.Seed, err = strconv.ParseInt(fields[0], 10, 64); err != nil {
+		return Replay{}, fmt.Errorf("bad replay seed %q", fields[0])
+	}
+	diff, size, sized := strings.Cut(fields[1], "@")
+	if sized {
+		_, err := fmt.Sscanf(size, "%dx%d", &r.W, &r.H)
+		if err != nil || r.W < minLevelW || r.W > maxLevelW || r.H < minLevelH || r.H > maxLevelH {
+			return Replay{}, fmt.Errorf("bad replay board size %q", size)
+		}
+	}
+	diff, r.Arcade = strings.CutSuffix(diff, "a")
+	if r.Diff, err = strconv.Atoi(diff); err != nil || r.Diff < 0 || r.Diff >= len(diffNames) {
+		return Replay{}, fmt.Errorf("bad replay difficulty %q", fields[1])
+	}
+	last := -1
+	for _, f := range fields[2:] {
+		dir := strings.IndexByte(dirLetters, f[len(f)-1])
+		step, err := strconv.Atoi(f[:len(f)-1])
+		if dir < 0 || err != nil || step <= last {
+			return Replay{}, fmt.Errorf("bad replay turn %q", f)
+		}
+		r.Turns = append(r.Turns, Turn{Step: step, Dir: Direction(dir)})
+		last = step
+	}
+	return r, nil
+}
+
+// The daily challenge is the same run for everyone each UTC day: its seed
+// is the date, e.g. 20261017, and it is played at dailyDiff.
+const dailyDiff = 1
+
+// dailySeed is the seed of the daily challenge on t's UTC day
+func dailySeed(t time.Time) int64 {
+	y, mo, d := t.UTC().Date()
+	return int64(y*10000 + int(mo)*100 + d)
+}
+
+// dailyBoard is the leaderboard of the daily challenge played on seed
+func dailyBoard(seed int64) string {
+	return fmt.Sprintf("snake-daily-%d", seed)
+}
+
+// dailyDate shows the day of a daily seed, e.g. "2026-10-17"
+func dailyDate(seed int64) string {
+	return fmt.Sprintf("%04d-%02d-%02d", seed/10000, seed/100%100, seed%100)
+}
@@ -0,0 +1,173 @@
+package snake
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+var dirKeys = [...]string{DirUp: "up", DirDown: "down", DirLeft: "left", DirRight: "right"}
+
+// steer picks a direction for the snake: towards the food if that is safe,
+// else any safe way, with now and then a random swerve so runs differ
+func steer(m *Model, rng *rand.Rand) Direction {
+	occ := make(map[Point]bool, len(m.snake))
+	for _, p := range m.snake[:len(m.snake)-1] {
+		occ[p] = true
+	}
+	safe := func(d Direction) bool {
+		p, ok := m.level.next(m.snake[0], d)
+		return ok && !m.level.Walls[p] && !occ[p]
+	}
+	head, food := m.snake[0], m.food
+	want := []Direction{}
+	switch {
+	case food.X < head.X:
+		want = append(want, DirLeft)
+	case food.X > head.X:
+		want = append(want, DirRight)
+	}
+	switch {
+	case food.Y < head.Y:
+		want = append(want, DirUp)
+	case food.Y > head.Y:
+		want = append(want, DirDown)
+	}
+	if rng.Intn(10) == 0 {
+		want = nil
+	}
+	for _, i := range rng.Perm(4) {
+		want = append(want, Direction(i))
+	}
+	for _, d := range want {
+		if safe(d) {
+			return d
+		}
+	}
+	return m.dir
+}
+
+// playRun plays a run from seed to the end, steering it with rng, and
+// returns the model at game over and the replay the game would save
+func playRun(t *testing.T, m Model, seed int64, rng *rand.Rand) (Model, string) {
+	t.Helper()
+	m.startRun(seed)
+	for tick := 0; m.State == StatePlaying; tick++ {
+		if tick > 1_000_000 {
+			t.Fatalf("run from seed %d never ended", seed)
+		}
+		if d := steer(&m, rng); d != m.dir {
+			m.handleKey(dirKeys[d])
+		}
+		m, _ = m.Update(TickMsg{})
+	}
+	record := Replay{Seed: m.seed, Diff: m.diff, Arcade: m.arcade, Turns: m.turns}
+	if m.level.W != boardW || m.level.H != boardH {
+		record.W, record.H = m.level.W, m.level.H
+	}
+	return m, record.String()
+}
+
+// watchRun plays replay back to its end
+func watchRun(t *testing.T, replay string) Model {
+	t.Helper()
+	m := InitialModel()
+	m.watch(game.Score{Replay: replay})
+	if m.watching == nil {
+		t.Fatalf("replay %q didn't parse", replay)
+	}
+	for tick := 0; m.State == StatePlaying; tick++ {
+		if tick > 1_000_000 {
+			t.Fatalf("replay never ended")
+		}
+		m, _ = m.Update(TickMsg{})
+	}
+	return m
+}
+
+func TestReplayReproducesRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		diff   int
+		arcade bool
+		w, h   int
+	}{
+		{"easy", 0, false, boardW, boardH},
+		{"hard", 2, false, boardW, boardH},
+		{"arcade", 1, true, boardW, boardH},
+		{"small board", 1, false, 36, 16},
+		{"huge arcade", 1, true, 60, 38},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eaten := 0
+			for seed := int64(1); seed <= 20; seed++ {
+				m := InitialModel()
+				m.diff, m.arcade = tt.diff, tt.arcade
+				m.playOn(openBoard("", tt.w, tt.h), -1, false)
+				played, replay := playRun(t, m, seed, rand.New(rand.NewSource(seed)))
+				eaten += played.score
+				watched := watchRun(t, replay)
+				if watched.score != played.score || watched.steps != played.steps || len(watched.snake) != len(played.snake) {
+					t.Errorf("seed %d: played %d points in %d steps to length %d, replay %d in %d to %d",
+						seed, played.score, played.steps, len(played.snake), watched.score, watched.steps, len(watched.snake))
+				}
+				if watched.stats.eaten != played.stats.eaten {
+					t.Errorf("seed %d: played ate %v, replay %v", seed, played.stats.eaten, watched.stats.eaten)
+				}
+			}
+			if eaten == 0 {
+				t.Error("no run ate anything, so no replay was tested past its start")
+			}
+		})
+	}
+}
+
+func TestReplayString(t *testing.T) {
+	tests := []struct {
+		text string
+		want Replay
+	}{
+		{"20261017 1", Replay{Seed: 20261017, Diff: 1}},
+		{"-5 0 4u 9l 15d", Replay{Seed: -5, Diff: 0, Turns: []Turn{{4, DirUp}, {9, DirLeft}, {15, DirDown}}}},
+		{"7 1a 0r", Replay{Seed: 7, Diff: 1, Arcade: true, Turns: []Turn{{0, DirRight}}}},
+		{"7 2@44x28 3d", Replay{Seed: 7, Diff: 2, W: 44, H: 28, Turns: []Turn{{3, DirDown}}}},
+		{"7 1a@60x38", Replay{Seed: 7, Diff: 1, Arcade: true, W: 60, H: 38}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			r, err := ParseReplay(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.String() != tt.text || r.Seed != tt.want.Seed || r.Diff != tt.want.Diff ||
+				r.Arcade != tt.want.Arcade || r.W != tt.want.W || r.H != tt.want.H || len(r.Turns) != len(tt.want.Turns) {
+				t.Errorf("ParseReplay = %+v (%q), want %+v", r, r.String(), tt.want)
+			}
+			for i := range r.Turns {
+				if i < len(tt.want.Turns) && r.Turns[i] != tt.want.Turns[i] {
+					t.Errorf("turn %d = %+v, want %+v", i, r.Turns[i], tt.want.Turns[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReplayRejects(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"7",
+		"seven 1",
+		"7 3",
+		"7 1@4x4",
+		"7 1@80x20",
+		"7 1 4x",
+		"7 1 9u 4d",
+		"7 1 4u 4d",
+	} {
+		if _, err := ParseReplay(text); err == nil {
+			t.Errorf("ParseReplay(%q) took it", text)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package snake
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// boardSize is a size the board of a difficulty or an arcade run can be.
+// Each size has its own leaderboards, since food is further apart on a
+// bigger board and there is more room to grow.
+type boardSize struct {
+	name string
+	w, h int
+}
+
+// boardSizes run from the one that fits an 80×24 terminal up. Runs are
+// played on the biggest that fits, unless the player picks a smaller one.
+var boardSizes = []boardSize{
+	{"Small", 36, 16},
+	{"Classic", boardW, boardH},
+	{"Large", 44, 28},
+	{"Huge", 60, 38},
+}
+
+// The frame round a board: the score bar, status line and borders, and
+// the width its status lines need however narrow the board is
+const (
+	frameW, frameH = 6, 6
+	minContentW    = 60
+)
+
+// needed is the terminal size a board of w×h cells takes to play on
+func needed(w, h int) (int, int) {
+	return max(2*w, minContentW) + frameW, h + frameH
+}
+
+// fits reports whether a board of w×h cells fits the terminal; it does if
+// the terminal's size isn't known yet
+func (m Model) fits(w, h int) bool {
+	nw, nh := needed(w, h)
+	return m.TermW == 0 || nw <= m.TermW && nh <= m.TermH
+}
+
+// runSize is the index of the board size the next run would be played on:
+// the one picked if it still fits, else the biggest that does, or -1 when
+// none do
+func (m Model) runSize() int {
+	if m.sizePick >= 0 && m.fits(boardSizes[m.sizePick].w, boardSizes[m.sizePick].h) {
+		return m.sizePick
+	}
+	best := -1
+	for i, s := range boardSizes {
+		if m.fits(s.w, s.h) && (best < 0 || s.w*s.h > boardSizes[best].w*boardSizes[best].h) {
+			best = i
+		}
+	}
+	return best
+}
+
+// menuSize is the size whose leaderboards the menu shows, which is the
+// classic one while the terminal is too small for any
+func (m Model) menuSize() boardSize {
+	if i := m.runSize(); i >= 0 {
+		return boardSizes[i]
+	}
+	return boardSizes[1]
+}
+
+// nextSize moves the player's pick on to the next size that fits, going
+// back to picking automatically after the last
+func (m *Model) nextSize() {
+	for i := m.sizePick + 1; i < len(boardSizes); i++ {
+		if m.fits(boardSizes[i].w, boardSizes[i].h) {
+			m.sizePick = i
+			return
+		}
+	}
+	m.sizePick = -1
+}
+
+// sizedBoard is the leaderboard called board for runs on a w×h board. The
+// classic size keeps the plain name, so scores from before boards were
+// sized stay on it.
+func sizedBoard(board string, w, h int) string {
+	if w == boardW && h == boardH {
+		return board
+	}
+	for _, s := range boardSizes {
+		if s.w == w && s.h == h {
+			return board + "-" + strings.ToLower(s.name)
+		}
+	}
+	return fmt.Sprintf("%s-%dx%d", board, w, h)
+}
+
+// sizedTitle adds the size of a board to a leaderboard's title, if it
+// isn't the classic one
+func sizedTitle(title string, w, h int) string {
+	if w == boardW && h == boardH {
+		return title
+	}
+	for _, s := range boardSizes {
+		if s.w == w && s.h == h {
+			return title + " · " + s.name
+		}
+	}
+	return fmt.Sprintf("%s · %d×%d", title, w, h)
+}
+
+// tooSmall reports whether what the current screen shows doesn't fit the
+// terminal: a run's board, the level being drawn, or on the menu, any board
+// at all
+func (m Model) tooSmall() bool {
+	switch m.State {
+	case StatePlaying, StatePaused, StateGameOver:
+		return !m.fits(m.level.W, m.level.H)
+	case StateEditor:
+		return !m.fits(m.editor.level.W, m.editor.level.H+2)
+	case StateMenu:
+		return m.runSize() < 0
+	}
+	return false
+}
+
+// renderTooSmall asks for a bigger terminal, saying how big
+func (m Model) renderTooSmall() string {
+	var want []string
+	switch m.State {
+	case StatePlaying, StatePaused, StateGameOver, StateEditor:
+		l := m.level
+		if m.State == StateEditor {
+			l = m.editor.level
+		}
+		w, h := needed(l.W, l.H)
+		if m.State == StateEditor {
+			h += 2
+		}
+		want = append(want, fmt.Sprintf("%d×%d", w, h))
+	default:
+		// Any size will do, so list those no other fits inside
+		for i, s := range boardSizes {
+			w, h := needed(s.w, s.h)
+			smallest := true
+			for j, o := range boardSizes {
+				ow, oh := needed(o.w, o.h)
+				if j != i && ow <= w && oh <= h && (ow < w || oh < h) {
+					smallest = false
+				}
+			}
+			if smallest {
+				want = append(want, fmt.Sprintf("%d×%d", w, h))
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(deadSty.Render("TERMINAL TOO SMALL"))
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("Snake needs at least %s,\n", strings.Join(want, " or ")))
+	sb.WriteString(fmt.Sprintf("but this terminal is %d×%d.", m.TermW, m.TermH))
+	sb.WriteString("\n\n")
+	sb.WriteString(helpSty.Render("Make the window bigger or the font smaller."))
+	sb.WriteString("\n")
+	sb.WriteString(helpSty.Render("Q: quit"))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorDead).
+		Padding(0, 1).
+		Render(sb.String())
+}
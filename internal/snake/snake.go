@@ -6,6 +6,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aminshahid573/termplay/internal/game"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -23,6 +25,9 @@ const uiTick = 60 * time.Millisecond
 var diffMoveEvery = [3]int{6, 4, 2}
 var diffNames = [3]string{"Easy", "Normal", "Hard"}
 var diffColors = [3]lipgloss.Color{"#44dd88", "#f0e040", "#ff4444"}
+var dailyColor = lipgloss.Color("#c084fc")
+var campaignColor = lipgloss.Color("#38bdf8")
+var levelsColor = lipgloss.Color("#fb923c")
 
 // ── Palette ──────────────────────────────────
 var (
@@ -37,6 +42,9 @@ var (
 	colorDead       = lipgloss.Color("#ff4040")
 	colorDim        = lipgloss.Color("#3a3a5c")
 	colorGhost      = lipgloss.Color("#2a2a45")
+	colorWall       = lipgloss.Color("#6b6b9a")
+	colorPortal     = lipgloss.Color("#38bdf8")
+	colorPortalBg   = lipgloss.Color("#0c2d48")
 )
 
 // ─────────────────────────────────────────────
@@ -64,11 +72,41 @@ const (
 	StatePlaying
 	StatePaused
 	StateGameOver
+	StateReplays  // Picking a top run to watch
+	StateCampaign // Picking a campaign level
+	StateLevels   // Picking one of the player's own levels
+	StateEditor   // Drawing a level
+)
+
+// The menu offers each difficulty, the daily challenge and arcade runs,
+// which have leaderboards, then the campaign and the player's own levels
+const (
+	modeDaily    = len(diffNames)
+	modeArcade   = modeDaily + 1
+	modeCount    = modeArcade + 1
+	modeCampaign = modeCount
+	modeLevels   = modeCampaign + 1
+	menuCount    = modeLevels + 1
 )
 
 // TickMsg is the message sent on each UI tick.
 type TickMsg struct{}
 
+// leaderboardMsg brings a leaderboard from the store
+type leaderboardMsg struct {
+	name  string
+	board game.Leaderboard
+	err   error
+}
+
+// levelsMsg brings the player's own levels from the store, after note was
+// done to them
+type levelsMsg struct {
+	levels map[string]string
+	note   string
+	err    error
+}
+
 // ─────────────────────────────────────────────
 //  Model
 // ─────────────────────────────────────────────
@@ -83,62 +121,173 @@ type Model struct {
 	nextDir   Direction
 	food      Point
 	score     int
-	highscore int
+	highscore map[string]int // per leaderboard, the player's best ever
 	State     GameState
-	diff      int // 0=Easy 1=Normal 2=Hard
+	diff      int  // 0=Easy 1=Normal 2=Hard
+	daily     bool // Playing the daily challenge
+	arcade    bool // Playing with power-ups and speeding up
+
+	// the food's kind and the steps left before a power-up goes plain, the
+	// steps left on each timed power-up, and the run's stats so far
+	foodKind Food
+	foodLeft int
+	effects  [foodKinds]int
+	stats    runStats
+
+	// the level played on, the campaign level it is or -1, and whether the
+	// run has reached that level's target; or the player's own level. filled
+	// is set when a run ends with no free cell left for food.
+	level    Level
+	campaign int
+	cleared  bool
+	custom   bool
+	filled   bool
+
+	// the run so far: its seed, steps taken and the turns to replay it
+	seed  int64
+	steps int
+	turns []Turn
+
+	// a run being watched instead of played, and how far into its turns
+	watching *game.Score
+	replay   Replay
+	replayAt int
+	fast     bool
 
 	// animation / movement counters
 	uiFrame  int // incremented every uiTick
-	moveAccu int // snake steps when this reaches diffMoveEvery[diff]
+	moveAccu int // snake steps when this reaches moveEvery()
 	foodAnim int
 
 	// menu
-	menuSel int
+	menuSel  int
+	listSel  int // Run picked in StateReplays
+	sizePick int // Board size picked for runs, or -1 for the biggest that fits
+
+	// the leaderboard StateReplays lists runs from, as it was when the list
+	// was opened; a resize or midnight can move the menu to another board
+	replays      *game.Leaderboard
+	replaysTitle string
 
 	// Whether the player wants to quit back to the game-select screen
 	WantsQuit bool
 
+	// leaderboards by name; nil until fetched, and scores is nil when
+	// there is nowhere to keep them
+	scores   game.Leaderboards
+	boards   map[string]*game.Leaderboard
+	boardErr error
+
+	// the player's own levels by name; nil until fetched, and library is
+	// nil when there is nowhere to keep them
+	library   game.Library
+	levels    map[string]Level
+	levelsErr error
+	note      string // What the last change to them came to
+	deleting  bool   // D was pressed once on the level picked
+
+	// editor is open in StateEditor, and stays open while its level is
+	// being tried out
+	editor *editor
+
 	rng *rand.Rand
 }
 
 const boardW, boardH = 30, 20
 
+// leaderboardSize is how many of the top scores are shown
+const leaderboardSize = 5
+
 // buildSnake resets only game-board state, keeping meta fields intact.
 func (m *Model) buildSnake() {
-	m.snake = []Point{
-		{boardW/2 + 1, boardH / 2},
-		{boardW / 2, boardH / 2},
-		{boardW/2 - 1, boardH / 2},
-	}
+	m.snake = m.level.body()
 	m.dir = DirRight
 	m.nextDir = DirRight
 	m.score = 0
 	m.moveAccu = 0
-	m.food = m.spawnFood()
+	m.effects = [foodKinds]int{}
+	m.stats = runStats{longest: len(m.snake)}
+	m.placeFood() // Levels are checked to leave room for it
+}
+
+// startRun starts a run whose food all comes from seed
+func (m *Model) startRun(seed int64) {
+	m.seed = seed
+	m.rng = rand.New(rand.NewSource(seed))
+	m.steps, m.turns, m.replayAt = 0, nil, 0
+	m.cleared, m.filled = false, false
+	m.buildSnake()
+	m.State = StatePlaying
+}
+
+// watch plays back a top run, if it has a replay
+func (m *Model) watch(sc game.Score) {
+	r, err := ParseReplay(sc.Replay)
+	if err != nil {
+		return
+	}
+	m.watching, m.replay = &sc, r
+	m.diff, m.arcade, m.fast = r.Diff, r.Arcade, false
+	l := Classic()
+	if r.W > 0 {
+		l = openBoard("", r.W, r.H)
+	}
+	m.playOn(l, -1, false)
+	m.startRun(r.Seed)
+}
+
+// playOn sets the level the next runs are played on
+func (m *Model) playOn(l Level, campaign int, custom bool) {
+	m.level, m.campaign, m.custom = l, campaign, custom
+}
+
+// playCampaign starts campaign level i, at normal speed
+func (m *Model) playCampaign(i int) {
+	m.watching, m.daily, m.arcade, m.diff = nil, false, false, 1
+	m.playOn(campaign[i], i, false)
+	m.startRun(time.Now().UnixNano())
+}
+
+// playCustom starts a run on one of the player's own levels, at normal speed
+func (m *Model) playCustom(l Level) {
+	m.watching, m.daily, m.arcade, m.diff = nil, false, false, 1
+	m.playOn(l, -1, true)
+	m.startRun(time.Now().UnixNano())
 }
 
 // InitialModel creates a fresh snake game model.
 func InitialModel() Model {
 	m := Model{
-		State:   StateMenu,
-		menuSel: 1,
-		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		State:     StateMenu,
+		menuSel:   1,
+		level:     Classic(),
+		campaign:  -1,
+		sizePick:  -1,
+		highscore: make(map[string]int),
+		boards:    make(map[string]*game.Leaderboard),
+		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 	m.buildSnake()
 	return m
 }
 
-func (m *Model) spawnFood() Point {
+func (m *Model) spawnFood() (Point, bool) {
 	occ := map[Point]bool{}
 	for _, p := range m.snake {
 		occ[p] = true
 	}
-	for {
-		p := Point{m.rng.Intn(boardW), m.rng.Intn(boardH)}
-		if !occ[p] {
-			return p
+	var free []Point
+	for y := 0; y < m.level.H; y++ {
+		for x := 0; x < m.level.W; x++ {
+			if p := (Point{x, y}); !occ[p] && m.level.open(p) {
+				free = append(free, p)
+			}
 		}
 	}
+	if len(free) == 0 {
+		return Point{}, false
+	}
+	return free[m.rng.Intn(len(free))], true
 }
 
 // ─────────────────────────────────────────────
@@ -150,6 +299,73 @@ func TickCmd() tea.Cmd {
 	return tea.Tick(uiTick, func(_ time.Time) tea.Msg { return TickMsg{} })
 }
 
+// ─────────────────────────────────────────────
+//  Leaderboards
+// ─────────────────────────────────────────────
+
+// boardName is the leaderboard of a difficulty, e.g. "snake-hard"
+func boardName(diff int) string {
+	return "snake-" + strings.ToLower(diffNames[diff])
+}
+
+// modeBoard is the leaderboard of a menu entry, at the size a run would be
+// played at now; for the daily challenge, today's
+func (m Model) modeBoard(mode int) string {
+	size := m.menuSize()
+	switch mode {
+	case modeDaily:
+		return dailyBoard(dailySeed(time.Now()))
+	case modeArcade:
+		return sizedBoard(arcadeBoard, size.w, size.h)
+	}
+	return sizedBoard(boardName(mode), size.w, size.h)
+}
+
+// runBoard is the leaderboard the current run counts for, or "" for the
+// player's own levels, which have none
+func (m Model) runBoard() string {
+	switch {
+	case m.custom:
+		return ""
+	case m.campaign >= 0:
+		return campaignBoard(m.campaign)
+	case m.daily:
+		return dailyBoard(m.seed)
+	case m.arcade:
+		return sizedBoard(arcadeBoard, m.level.W, m.level.H)
+	}
+	return sizedBoard(boardName(m.diff), m.level.W, m.level.H)
+}
+
+// loadBoardsCmd fetches the leaderboards of every menu entry
+func (m Model) loadBoardsCmd() tea.Cmd {
+	if m.scores == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for mode := range modeCount {
+		cmds = append(cmds, scoreCmd(m.scores, m.modeBoard(mode), 0, ""))
+	}
+	for i := range campaign {
+		cmds = append(cmds, scoreCmd(m.scores, campaignBoard(i), 0, ""))
+	}
+	return tea.Batch(cmds...)
+}
+
+// scoreCmd records score and its replay, if there is a score, on the
+// leaderboard called name and then fetches that leaderboard
+func scoreCmd(scores game.Leaderboards, name string, score int, replay string) tea.Cmd {
+	return func() tea.Msg {
+		if score > 0 {
+			if err := scores.Submit(name, score, replay); err != nil {
+				return leaderboardMsg{name: name, err: err}
+			}
+		}
+		lb, err := scores.Leaderboard(name, leaderboardSize)
+		return leaderboardMsg{name: name, board: lb, err: err}
+	}
+}
+
 // ─────────────────────────────────────────────
 //  Update
 // ─────────────────────────────────────────────
@@ -161,7 +377,40 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		k := msg.String()
-		m.handleKey(k)
+		wasOver := m.State == StateGameOver
+		cmd := m.handleKey(k)
+		if wasOver && (m.State == StateMenu || m.State == StateCampaign) {
+			// Others may have played since; show the boards as they are now
+			return m, tea.Batch(cmd, m.loadBoardsCmd())
+		}
+		return m, cmd
+
+	case tea.WindowSizeMsg:
+		before := m.runSize()
+		m.TermW, m.TermH = msg.Width, msg.Height
+		if m.runSize() != before {
+			// The menu's leaderboards are for the size that fits now
+			return m, m.loadBoardsCmd()
+		}
+		return m, nil
+
+	case levelsMsg:
+		m.levelsErr = msg.err
+		m.note = msg.note
+		if msg.err == nil {
+			m.levels = parseLevels(msg.levels)
+		}
+		if m.editor != nil {
+			m.editor.saved(msg.err)
+		}
+		return m, nil
+
+	case leaderboardMsg:
+		m.boardErr = msg.err
+		if msg.err == nil {
+			m.boards[msg.name] = &msg.board
+			m.highscore[msg.name] = max(m.highscore[msg.name], msg.board.Mine.Score)
+		}
 		return m, nil
 
 	case TickMsg:
@@ -170,49 +419,154 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.foodAnim = (m.uiFrame / 2) % 4
 
 		// ── advance snake (only while playing) ──
+		var save tea.Cmd
+		if m.State == StatePlaying && m.tooSmall() {
+			// Wait for the terminal to grow back rather than play blind
+			m.State = StatePaused
+		}
 		if m.State == StatePlaying {
 			m.moveAccu++
-			if m.moveAccu >= diffMoveEvery[m.diff] {
+			m.stats.ticks++
+			if m.moveAccu >= m.moveEvery() {
 				m.moveAccu = 0
 				if m.stepSnake() {
 					m.State = StateGameOver
+					if board := m.runBoard(); m.scores != nil && m.watching == nil && board != "" {
+						// Replays are kept for open boards of any size; a campaign
+						// level's walls and portals aren't part of one
+						var replay string
+						if m.campaign < 0 {
+							record := Replay{Seed: m.seed, Diff: m.diff, Arcade: m.arcade, Turns: m.turns}
+							if m.level.W != boardW || m.level.H != boardH {
+								record.W, record.H = m.level.W, m.level.H
+							}
+							replay = record.String()
+						}
+						save = scoreCmd(m.scores, board, m.score, replay)
+					}
 				}
 			}
 		}
 
 		// Reschedule the ticker
-		return m, TickCmd()
+		return m, tea.Batch(TickCmd(), save)
 	}
 
 	return m, nil
 }
 
-// handleKey is a plain method (no tea.Cmd) so it can never accidentally
-// spawn a new ticker.
-func (m *Model) handleKey(k string) {
+// handleKey only ever returns store commands, never a tick, so it can never
+// accidentally spawn a new ticker.
+func (m *Model) handleKey(k string) tea.Cmd {
+	if m.tooSmall() && k != "q" && k != "esc" {
+		return nil
+	}
 	switch m.State {
 
 	case StateMenu:
 		switch k {
 		case "up", "w", "k":
-			m.menuSel = (m.menuSel + 2) % 3
+			m.menuSel = (m.menuSel + menuCount - 1) % menuCount
 		case "down", "s", "j":
-			m.menuSel = (m.menuSel + 1) % 3
-		case "1":
-			m.menuSel = 0
-		case "2":
-			m.menuSel = 1
-		case "3":
-			m.menuSel = 2
+			m.menuSel = (m.menuSel + 1) % menuCount
+		case "1", "2", "3", "4", "5", "6", "7":
+			m.menuSel = int(k[0] - '1')
 		case "enter", " ":
-			m.diff = m.menuSel
-			m.buildSnake()
-			m.State = StatePlaying
+			switch m.menuSel {
+			case modeCampaign:
+				m.State, m.listSel = StateCampaign, 0
+				return nil
+			case modeLevels:
+				m.State, m.listSel, m.note, m.deleting = StateLevels, 0, "", false
+				return m.loadLevelsCmd()
+			}
+			m.watching = nil
+			m.daily = m.menuSel == modeDaily
+			m.arcade = m.menuSel == modeArcade
+			if m.daily {
+				m.playOn(Classic(), -1, false)
+			} else {
+				size := boardSizes[m.runSize()]
+				m.playOn(openBoard(size.name, size.w, size.h), -1, false)
+			}
+			switch {
+			case m.daily:
+				m.diff = dailyDiff
+				m.startRun(dailySeed(time.Now()))
+			case m.arcade:
+				// Arcade runs set their own pace, which starts below Normal
+				m.diff = 1
+				m.startRun(time.Now().UnixNano())
+			default:
+				m.diff = m.menuSel
+				m.startRun(time.Now().UnixNano())
+			}
+		case "z":
+			m.nextSize()
+			return m.loadBoardsCmd()
+		case "v":
+			if m.menuSel >= modeCount {
+				return nil
+			}
+			if lb := m.boards[m.modeBoard(m.menuSel)]; lb != nil && len(lb.Top) > 0 {
+				m.State = StateReplays
+				m.listSel = 0
+				m.replays, m.replaysTitle = lb, m.modeTitle(m.menuSel)
+			}
 		case "q", "esc":
 			m.WantsQuit = true
 		}
 
+	case StateReplays:
+		if m.replays == nil || len(m.replays.Top) == 0 {
+			m.State = StateMenu
+			return nil
+		}
+		top := m.replays.Top
+		m.listSel = min(max(m.listSel, 0), len(top)-1)
+		switch k {
+		case "up", "w", "k":
+			m.listSel = max(m.listSel-1, 0)
+		case "down", "s", "j":
+			m.listSel = min(m.listSel+1, len(top)-1)
+		case "enter", " ":
+			m.watch(top[m.listSel])
+		case "q", "esc", "v":
+			m.State = StateMenu
+		}
+
+	case StateCampaign:
+		switch k {
+		case "up", "w", "k":
+			m.listSel = max(m.listSel-1, 0)
+		case "down", "s", "j":
+			m.listSel = min(m.listSel+1, len(campaign)-1)
+		case "enter", " ":
+			if m.unlocked(m.listSel) {
+				m.playCampaign(m.listSel)
+			}
+		case "q", "esc":
+			m.State = StateMenu
+		}
+
+	case StateLevels:
+		return m.levelsKey(k)
+
+	case StateEditor:
+		return m.editorKey(k)
+
 	case StatePlaying:
+		if m.watching != nil {
+			switch k {
+			case "f":
+				m.fast = !m.fast
+			case "p", "escape":
+				m.State = StatePaused
+			case "q", "esc":
+				m.State = StateReplays
+			}
+			return nil
+		}
 		switch k {
 		case "up", "w", "k":
 			if m.dir != DirDown {
@@ -239,46 +593,99 @@ func (m *Model) handleKey(k string) {
 		case "p", "escape", "enter":
 			m.State = StatePlaying
 		case "q":
-			m.WantsQuit = true
+			switch {
+			case m.watching != nil:
+				m.State = StateReplays
+			case m.editor != nil:
+				m.State = StateEditor
+			default:
+				m.WantsQuit = true
+			}
 		}
 
 	case StateGameOver:
+		if m.watching != nil {
+			switch k {
+			case "enter", " ", "q", "esc":
+				m.State = StateReplays
+			case "r":
+				m.watch(*m.watching)
+			}
+			return nil
+		}
 		switch k {
 		case "enter", " ", "r":
-			m.buildSnake()
-			m.State = StatePlaying
-		case "m":
-			hs := m.highscore
-			tw, th := m.TermW, m.TermH
-			*m = InitialModel()
-			m.highscore = hs
-			m.TermW, m.TermH = tw, th
+			switch {
+			case k != "r" && m.campaign >= 0 && m.cleared && m.campaign+1 < len(campaign):
+				m.playCampaign(m.campaign + 1)
+			case m.daily:
+				// The daily challenge is the same run every try
+				m.startRun(m.seed)
+			default:
+				m.startRun(time.Now().UnixNano())
+			}
+		case "m", "esc":
+			switch {
+			case m.editor != nil:
+				m.State = StateEditor
+			case m.campaign >= 0:
+				m.State, m.listSel = StateCampaign, m.campaign
+			case m.custom:
+				m.State = StateLevels
+			default:
+				m.toMenu()
+			}
 		case "q":
+			if m.editor != nil {
+				m.State = StateEditor
+				return nil
+			}
 			m.WantsQuit = true
 		}
 	}
+	return nil
 }
 
-// stepSnake advances the snake one cell. Returns true if the snake died.
+// toMenu starts the model afresh on the menu, keeping what it has fetched
+// from the store
+func (m *Model) toMenu() {
+	keep := *m
+	*m = InitialModel()
+	m.highscore, m.scores, m.boards = keep.highscore, keep.scores, keep.boards
+	m.library, m.levels = keep.library, keep.levels
+	m.sizePick = keep.sizePick
+	m.TermW, m.TermH = keep.TermW, keep.TermH
+}
+
+// unlocked reports whether campaign level i can be played: the first always
+// can, and each one after once the one before has reached its target
+func (m Model) unlocked(i int) bool {
+	return i == 0 || m.highscore[campaignBoard(i-1)] >= campaign[i-1].Target
+}
+
+// stepSnake advances the snake one cell. Returns true if the run is over:
+// the snake died, or it has left no room for more food. The turn it takes
+// is recorded, or while watching, read from the replay.
 func (m *Model) stepSnake() bool {
+	if m.watching != nil {
+		for ; m.replayAt < len(m.replay.Turns) && m.replay.Turns[m.replayAt].Step <= m.steps; m.replayAt++ {
+			m.nextDir = m.replay.Turns[m.replayAt].Dir
+		}
+	} else if m.nextDir != m.dir {
+		m.turns = append(m.turns, Turn{Step: m.steps, Dir: m.nextDir})
+	}
+	m.steps++
+
+	ghost := m.effects[FoodGhost] > 0
+	m.wearOff()
+
 	m.dir = m.nextDir
-	head := m.snake[0]
-	switch m.dir {
-	case DirUp:
-		head.Y--
-	case DirDown:
-		head.Y++
-	case DirLeft:
-		head.X--
-	case DirRight:
-		head.X++
-	}
-
-	if head.X < 0 || head.X >= boardW || head.Y < 0 || head.Y >= boardH {
+	head, ok := m.level.next(m.snake[0], m.dir)
+	if !ok || m.level.Walls[head] {
 		return true
 	}
 	for _, p := range m.snake {
-		if p == head {
+		if p == head && !ghost {
 			return true
 		}
 	}
@@ -288,15 +695,22 @@ func (m *Model) stepSnake() bool {
 	ns = append(ns, head)
 	if ate {
 		ns = append(ns, m.snake...)
-		m.score += 10
-		if m.score > m.highscore {
-			m.highscore = m.score
+		m.snake = ns
+		m.eat()
+		m.stats.longest = max(m.stats.longest, len(ns))
+		if board := m.runBoard(); m.watching == nil && board != "" && m.score > m.highscore[board] {
+			m.highscore[board] = m.score
 		}
-		m.food = m.spawnFood()
-	} else {
-		ns = append(ns, m.snake[:len(m.snake)-1]...)
+		if m.campaign >= 0 && m.score >= m.level.Target {
+			m.cleared = true
+		}
+		if !m.placeFood() {
+			m.filled = true
+			return true
+		}
+		return false
 	}
-	m.snake = ns
+	m.snake = append(ns, m.snake[:len(m.snake)-1]...)
 	return false
 }
 
@@ -348,16 +762,44 @@ func emptyCell() string {
 	return lipgloss.NewStyle().Foreground(colorGhost).Render("··")
 }
 
+func wallCell() string {
+	return lipgloss.NewStyle().Foreground(colorWall).Render("▓▓")
+}
+
+func portalCell(letter byte) string {
+	return lipgloss.NewStyle().Foreground(colorPortal).Background(colorPortalBg).Bold(true).Render(" " + string(letter))
+}
+
+// levelCell draws what the level itself has at p, given its portal letters
+func levelCell(l Level, letters map[Point]byte, p Point) string {
+	if l.Walls[p] {
+		return wallCell()
+	}
+	if letter, ok := letters[p]; ok {
+		return portalCell(letter)
+	}
+	return emptyCell()
+}
+
 // ─────────────────────────────────────────────
 //  View
 // ─────────────────────────────────────────────
 
 // View renders the snake game.
 func (m Model) View() string {
+	if m.tooSmall() {
+		return m.renderTooSmall()
+	}
 	var content string
 	switch m.State {
-	case StateMenu:
+	case StateMenu, StateReplays:
 		content = m.renderMenu()
+	case StateCampaign:
+		content = m.renderCampaign()
+	case StateLevels:
+		content = m.renderLevels()
+	case StateEditor:
+		content = m.renderEditor()
 	default:
 		content = m.renderGame()
 	}
@@ -397,13 +839,14 @@ func (m Model) renderMenu() string {
 	sb.WriteString(dimSty.Render("  ─────────────────"))
 	sb.WriteString("\n")
 
-	for i, name := range diffNames {
+	for i := range menuCount {
+		name := modeName(i)
 		if i == m.menuSel {
 			bullet := lipgloss.NewStyle().
-				Foreground(diffColors[i]).
+				Foreground(modeColor(i)).
 				Bold(true)
 			label := lipgloss.NewStyle().
-				Foreground(diffColors[i]).
+				Foreground(modeColor(i)).
 				Bold(true).
 				Render("[" + name + "]")
 			line := bullet.Render("  ▶ ") + label
@@ -412,11 +855,56 @@ func (m Model) renderMenu() string {
 			plain := lipgloss.NewStyle().Foreground(colorDim)
 			sb.WriteString(plain.Render(fmt.Sprintf("    %s", name)))
 		}
+		switch i {
+		case modeDaily:
+			sb.WriteString(dimSty.Render("  " + dailyDate(dailySeed(time.Now()))))
+		case modeArcade:
+			sb.WriteString(dimSty.Render("  power-ups"))
+		case modeCampaign:
+			sb.WriteString(dimSty.Render(fmt.Sprintf("  %d/%d cleared", m.campaignCleared(), len(campaign))))
+		}
+		sb.WriteString("\n")
+	}
+	if m.menuSel == modeArcade {
+		sb.WriteString("\n")
+		sb.WriteString(renderLegend())
+		sb.WriteString("\n")
+	}
+	if m.menuSel < modeCount && m.menuSel != modeDaily {
+		size := m.menuSize()
+		how := "biggest that fits"
+		if m.sizePick >= 0 && m.runSize() == m.sizePick {
+			how = "picked"
+		}
+		sb.WriteString("\n")
+		sb.WriteString(dimSty.Render("  BOARD  ") +
+			scoreSty.Render(fmt.Sprintf("%s %d×%d", size.name, size.w, size.h)) +
+			dimSty.Render("  "+how) + keySty.Render("   Z change"))
+		sb.WriteString("\n")
+	}
+
+	lb := ""
+	switch {
+	case m.State == StateReplays:
+		lb = m.renderBoard(m.replays, m.replaysTitle, modeColor(m.menuSel), m.listSel)
+	case m.menuSel < modeCount:
+		lb = m.renderLeaderboard(m.modeBoard(m.menuSel), m.modeTitle(m.menuSel), modeColor(m.menuSel), -1)
+	}
+	if lb != "" {
+		sb.WriteString("\n")
+		sb.WriteString(lb)
 		sb.WriteString("\n")
 	}
 
 	sb.WriteString("\n")
-	sb.WriteString(keySty.Render("  ↑ ↓ to choose   ENTER to start"))
+	if m.State == StateReplays {
+		sb.WriteString(keySty.Render("  ↑ ↓ to choose   ENTER to watch   ESC back"))
+	} else {
+		sb.WriteString(keySty.Render("  ↑ ↓ to choose   ENTER to start"))
+		if m.scores != nil && m.menuSel < modeCount {
+			sb.WriteString(keySty.Render("   V replays"))
+		}
+	}
 	sb.WriteString("\n\n")
 	sb.WriteString(helpSty.Render("  Move: WASD / Arrows / HJKL"))
 	sb.WriteString("\n")
@@ -435,41 +923,105 @@ func (m Model) renderGame() string {
 	}
 	alive := m.State != StateGameOver
 
+	letters := m.level.portalLetters()
 	var board strings.Builder
-	for y := 0; y < boardH; y++ {
-		for x := 0; x < boardW; x++ {
+	for y := 0; y < m.level.H; y++ {
+		for x := 0; x < m.level.W; x++ {
 			p := Point{x, y}
 			if idx, ok := snakeSet[p]; ok {
-				board.WriteString(snakeCell(idx, alive))
+				cell := snakeCell(idx, alive)
+				if m.effects[FoodGhost] > 0 && alive && idx > 0 {
+					cell = lipgloss.NewStyle().Foreground(foodColors[FoodGhost]).Faint(true).Render("░░")
+				}
+				board.WriteString(cell)
+			} else if p == m.food && m.foodKind != FoodPlain {
+				board.WriteString(specialCell(m.foodKind, m.foodAnim, m.foodLeft))
 			} else if p == m.food {
 				board.WriteString(foodCell(m.foodAnim))
 			} else {
-				board.WriteString(emptyCell())
+				board.WriteString(levelCell(m.level, letters, p))
 			}
 		}
-		if y < boardH-1 {
+		if y < m.level.H-1 {
 			board.WriteString("\n")
 		}
 	}
 
+	mode, title := m.diff, diffNames[m.diff]
+	switch {
+	case m.daily && m.watching == nil:
+		mode, title = modeDaily, "Daily"
+	case m.arcade:
+		mode, title = modeArcade, fmt.Sprintf("Arcade %d/%d", speedTier(m.score)+1, len(arcadeSpeeds))
+	case m.campaign >= 0:
+		mode, title = modeCampaign, fmt.Sprintf("%d %s", m.campaign+1, m.level.Name)
+	case m.custom:
+		mode, title = modeLevels, m.level.Name
+	}
 	diffBadge := lipgloss.NewStyle().
-		Foreground(diffColors[m.diff]).
+		Foreground(modeColor(mode)).
 		Bold(true).
-		Render("[" + diffNames[m.diff] + "]")
-
-	scoreBar := fmt.Sprintf(" %s  %s    %s  %s    %s  %s",
+		Render("[" + title + "]")
+
+	best := fmt.Sprintf("%s  %s",
+		dimSty.Render("BEST"), scoreSty.Render(fmt.Sprintf("%06d", m.highscore[m.runBoard()])))
+	switch {
+	case m.watching != nil:
+		best = fmt.Sprintf("%s  %s",
+			dimSty.Render("REPLAY"), scoreSty.Render(m.watching.Name))
+	case m.campaign >= 0:
+		best = fmt.Sprintf("%s  %s",
+			dimSty.Render("TARGET"), scoreSty.Render(fmt.Sprintf("%06d", m.level.Target)))
+	case m.custom:
+		best = ""
+	}
+	scoreBar := fmt.Sprintf(" %s  %s    %s    %s  %s",
 		dimSty.Render("SCORE"), scoreSty.Render(fmt.Sprintf("%06d", m.score)),
-		dimSty.Render("BEST"), scoreSty.Render(fmt.Sprintf("%06d", m.highscore)),
+		best,
 		dimSty.Render("MODE"), diffBadge,
 	)
 
+	over := deadSty.Render("  ✕  GAME OVER  ")
+	if m.filled {
+		over = scoreSty.Render("  ★  BOARD FILLED  ")
+	}
 	var status string
-	switch m.State {
-	case StatePaused:
+	switch {
+	case m.State == StatePaused:
 		status = pauseSty.Render("  ⏸  PAUSED — press P or ESC to resume")
-	case StateGameOver:
-		status = deadSty.Render("  ✕  GAME OVER  ") +
+	case m.State == StateGameOver && m.watching != nil:
+		status = deadSty.Render("  ✕  REPLAY OVER  ") +
+			helpSty.Render("[ENTER] back to runs   [R] watch again")
+	case m.State == StateGameOver && m.editor != nil:
+		status = over +
+			helpSty.Render("[ENTER] restart   [M] back to editor")
+	case m.State == StateGameOver && m.cleared && m.campaign+1 < len(campaign):
+		status = scoreSty.Render("  ✓  LEVEL CLEARED  ") +
+			helpSty.Render("[ENTER] next level   [R] retry   [M] levels")
+	case m.State == StateGameOver && m.cleared:
+		status = scoreSty.Render("  ★  CAMPAIGN COMPLETE  ") +
+			helpSty.Render("[ENTER] play again   [M] levels")
+	case m.State == StateGameOver && m.campaign >= 0:
+		status = over +
+			helpSty.Render("[ENTER] retry   [M] levels   [Q] quit")
+	case m.State == StateGameOver && m.custom:
+		status = over +
+			helpSty.Render("[ENTER] restart   [M] my levels   [Q] quit")
+	case m.State == StateGameOver:
+		status = over +
 			helpSty.Render("[ENTER] restart   [M] menu   [Q] quit")
+	case m.watching != nil:
+		speed := "F: fast"
+		if m.fast {
+			speed = "F: normal speed"
+		}
+		status = helpSty.Render("  ▶ Watching   " + speed + "   P: pause   Q: stop")
+	case m.renderEffects() != "":
+		status = m.renderEffects()
+	case m.cleared:
+		status = scoreSty.Render("  ✓  Target reached!") + helpSty.Render("  Keep going for the leaderboard")
+	case m.level.Wrap:
+		status = helpSty.Render("  WASD/Arrows: move   P: pause   Edges wrap round")
 	default:
 		status = helpSty.Render("  WASD/Arrows: move   P: pause   Q: quit")
 	}
@@ -484,5 +1036,137 @@ func (m Model) renderGame() string {
 		boardRendered,
 		status,
 	)
+	if m.State == StateGameOver && m.watching == nil {
+		side := m.renderStats()
+		if lb := m.renderLeaderboard(m.runBoard(), m.runTitle(), modeColor(mode), -1); !m.custom && lb != "" {
+			side = lb + "\n\n" + side
+		}
+		// Beside the board when the terminal is wide enough, else below if
+		// it is tall enough, else in place of the board
+		switch {
+		case m.TermW > 0 && lipgloss.Width(inner)+lipgloss.Width(side)+6 <= m.TermW:
+			inner = lipgloss.JoinHorizontal(lipgloss.Top, inner, "  ", "\n"+side)
+		case m.TermH == 0 || lipgloss.Height(inner)+lipgloss.Height(side)+3 <= m.TermH:
+			inner = lipgloss.JoinVertical(lipgloss.Left, inner, "", side)
+		default:
+			inner = lipgloss.JoinVertical(lipgloss.Left, scoreBar, "", side, "", status)
+		}
+	}
 	return outerBox().Render(inner)
 }
+
+// ── Leaderboard ───────────────────────────────
+
+// modeName, modeTitle and modeColor describe a menu entry
+func modeName(mode int) string {
+	switch mode {
+	case modeDaily:
+		return "Daily Challenge"
+	case modeArcade:
+		return "Arcade"
+	case modeCampaign:
+		return "Campaign"
+	case modeLevels:
+		return "My Levels"
+	}
+	return diffNames[mode]
+}
+
+func (m Model) modeTitle(mode int) string {
+	size := m.menuSize()
+	switch mode {
+	case modeDaily:
+		return "Daily " + dailyDate(dailySeed(time.Now()))
+	case modeArcade:
+		return sizedTitle("Arcade", size.w, size.h)
+	}
+	return sizedTitle(diffNames[mode], size.w, size.h)
+}
+
+func modeColor(mode int) lipgloss.Color {
+	switch mode {
+	case modeDaily:
+		return dailyColor
+	case modeArcade:
+		return arcadeColor
+	case modeCampaign:
+		return campaignColor
+	case modeLevels:
+		return levelsColor
+	}
+	return diffColors[mode]
+}
+
+// runTitle names the leaderboard of the current run
+func (m Model) runTitle() string {
+	switch {
+	case m.campaign >= 0:
+		return campaignTitle(m.campaign)
+	case m.daily:
+		return "Daily " + dailyDate(m.seed)
+	case m.arcade:
+		return sizedTitle("Arcade", m.level.W, m.level.H)
+	}
+	return sizedTitle(diffNames[m.diff], m.level.W, m.level.H)
+}
+
+// renderLeaderboard lists the top scores on the leaderboard called name
+// with the player's own highlighted, and their place below if it is further
+// down. cursor marks a run to watch, or is -1. It is empty when there are
+// no leaderboards.
+func (m Model) renderLeaderboard(name, title string, color lipgloss.Color, cursor int) string {
+	return m.renderBoard(m.boards[name], title, color, cursor)
+}
+
+// renderBoard is renderLeaderboard for lb, which is nil until fetched
+func (m Model) renderBoard(lb *game.Leaderboard, title string, color lipgloss.Color, cursor int) string {
+	if m.scores == nil {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(dimSty.Render("  TOP SCORES · " + title))
+	sb.WriteString("\n")
+	sb.WriteString(dimSty.Render("  ─────────────────"))
+	sb.WriteString("\n")
+
+	switch {
+	case m.boardErr != nil && lb == nil:
+		sb.WriteString(helpSty.Render("  Leaderboard unavailable"))
+		return sb.String()
+	case lb == nil:
+		sb.WriteString(helpSty.Render("  Loading scores..."))
+		return sb.String()
+	case len(lb.Top) == 0:
+		sb.WriteString(helpSty.Render("  No scores yet. Be the first!"))
+		return sb.String()
+	}
+
+	entry := func(rank int, sc game.Score) string {
+		line := fmt.Sprintf("%2d. %-12s %06d", rank, sc.Name, sc.Score)
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#aaaaee"))
+		mark := "  "
+		if rank == lb.Rank {
+			style, mark = lipgloss.NewStyle().Foreground(color).Bold(true), "▶ "
+		}
+		if rank-1 == cursor {
+			style = style.Reverse(true)
+			if sc.Replay == "" {
+				line += "  no replay"
+			}
+		}
+		return style.Render(mark + line)
+	}
+	for i, sc := range lb.Top {
+		sb.WriteString(entry(i+1, sc))
+		if i < len(lb.Top)-1 {
+			sb.WriteString("\n")
+		}
+	}
+	if lb.Rank > len(lb.Top) {
+		sb.WriteString("\n")
+		sb.WriteString(dimSty.Render("    ⋮"))
+		sb.WriteString("\n")
+		sb.WriteString(entry(lb.Rank, lb.Mine))
+	}
+	return sb.String()
+}
@@ -22,6 +22,20 @@ var (
 	ChessSelected    = lipgloss.Color("#66CCFF")
 	ChessBlocked     = lipgloss.Color("#FF3333")
 	ChessCapture     = lipgloss.Color("#FF6666")
+
+	// Checkers pieces, on the chess board's squares
+	CheckersBlackPiece = lipgloss.Color("#1A1A1A")
+	CheckersWhitePiece = lipgloss.Color("#F5F5F5")
+
+	// Go board wood and its lines
+	GoBoard = lipgloss.Color("#DCB35C")
+	GoLine  = lipgloss.Color("#5C4A1E")
+
+	// Battleship grids
+	BattleshipWater = lipgloss.Color("#1E4E79")
+	BattleshipShip  = lipgloss.Color("#7F8C8D")
+	BattleshipMiss  = lipgloss.Color("#D0E4F5")
+	BattleshipSunk  = lipgloss.Color("#5A1010")
 )
 
 var (
@@ -70,6 +84,47 @@ var (
 		BorderForeground(colorGreen).
 		Background(lipgloss.Color("22"))
 
+	// Unboxed cells for boards too big to draw with Cell
+	CellCompact = lipgloss.NewStyle().
+			Width(3).
+			Align(lipgloss.Center)
+
+	CellCompactSelected = CellCompact.Copy().
+				Background(colorPurple)
+
+	CellCompactWin = CellCompact.Copy().
+			Background(lipgloss.Color("22"))
+
+	// Ultimate tic-tac-toe small boards
+	SubBoard = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(colorBorder).
+			Padding(0, 1)
+
+	SubBoardActive = SubBoard.Copy().
+			BorderForeground(colorPurple)
+
+	SubBoardCursor = SubBoard.Copy().
+			Border(lipgloss.ThickBorder()).
+			BorderForeground(colorHighlight)
+
+	SubBoardWin = SubBoard.Copy().
+			BorderForeground(colorGreen)
+
+	// Connect Four
+	DropBoard = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#3B6FD1"))
+
+	DropCell = lipgloss.NewStyle().
+			Align(lipgloss.Center, lipgloss.Center)
+
+	DropCellWin = DropCell.Copy().
+			Background(lipgloss.Color("22"))
+
+	RedDisc    = lipgloss.NewStyle().Foreground(lipgloss.Color("#E8413C")).Bold(true)
+	YellowDisc = lipgloss.NewStyle().Foreground(lipgloss.Color("#F2C14E")).Bold(true)
+
 	XStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
 	OStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
 	PopupBox = lipgloss.NewStyle().
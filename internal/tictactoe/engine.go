@@ -0,0 +1,159 @@
+package tictactoe
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/ai"
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Levels are the strengths offered for tic-tac-toe, weakest first.
+// Perfect is exact on 3×3; bigger boards run out of budget first.
+var Levels = []ai.Level{
+	{Name: "Easy"}, // Depth 0: any empty cell
+	{Name: "Medium", Depth: 2, Budget: time.Second, Noise: 4},
+	{Name: "Perfect", Depth: 9, Budget: 3 * time.Second},
+}
+
+// engine plays the computer's side in solo games
+type engine struct{}
+
+func (engine) Levels() []ai.Level {
+	return Levels
+}
+
+func (engine) Move(ctx context.Context, s game.State, lvl ai.Level) (string, bool) {
+	cell, ok := ai.Search[State, int](ctx, searcher{}, s.(State), lvl)
+	if !ok {
+		return "", false
+	}
+	return strconv.Itoa(cell), true
+}
+
+// searcher lets the search play m,n,k games through CheckWinner and
+// CheckDraw. Moves are cell indexes.
+type searcher struct{}
+
+// nearbyOnly is the board size past which only cells next to a mark are
+// worth trying; a far-off stone in Gomoku is never the best move
+const nearbyOnly = 16
+
+func (searcher) Moves(s State) []int {
+	if w, _ := s.Size.CheckWinner(s.Board); w != "" {
+		return nil
+	}
+	var moves []int
+	for i, v := range s.Board {
+		if isEmpty(v) && (s.Size.Cells() <= nearbyOnly || nextToMark(s, i)) {
+			moves = append(moves, i)
+		}
+	}
+	if len(moves) == 0 && !CheckDraw(s.Board) {
+		// Empty big board: open in the middle
+		return []int{s.Size.Rows/2*s.Size.Cols + s.Size.Cols/2}
+	}
+
+	// Cells on more lines first: centre, then corners, then edges on 3×3
+	sort.SliceStable(moves, func(i, j int) bool {
+		return linesThrough(s.Size, moves[i]) > linesThrough(s.Size, moves[j])
+	})
+	return moves
+}
+
+// Play only places the mark; Moves and Score look for the winner
+func (searcher) Play(s State, cell int) State {
+	s.Board = append([]string(nil), s.Board...)
+	s.Board[cell] = s.Turn
+	s.Turn = game.Other(s.Turn)
+	return s
+}
+
+func (searcher) Score(s State) (int, bool) {
+	if w, _ := s.Size.CheckWinner(s.Board); w != "" {
+		if w == s.Turn {
+			return ai.Win, true
+		}
+		return ai.Loss, true
+	}
+	if CheckDraw(s.Board) {
+		return 0, true
+	}
+
+	// Every K-cell window still open to one side only, weighted steeply
+	// by how full it is
+	score := 0
+	eachWindow(s.Size, func(cells []int) {
+		mine, theirs := 0, 0
+		for _, i := range cells {
+			switch v := s.Board[i]; {
+			case isEmpty(v):
+			case v == s.Turn:
+				mine++
+			default:
+				theirs++
+			}
+		}
+		if theirs == 0 && mine > 0 {
+			score += 1 << (2 * mine)
+		}
+		if mine == 0 && theirs > 0 {
+			score -= 1 << (2 * theirs)
+		}
+	})
+	return score, false
+}
+
+// nextToMark reports whether any of the eight cells around i holds a mark
+func nextToMark(s State, i int) bool {
+	r, c := i/s.Size.Cols, i%s.Size.Cols
+	for dr := -1; dr <= 1; dr++ {
+		for dc := -1; dc <= 1; dc++ {
+			rr, cc := r+dr, c+dc
+			if inBounds(s.Size, rr, cc) && !isEmpty(s.Board[rr*s.Size.Cols+cc]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// linesThrough counts the winning windows that include cell i
+func linesThrough(size Size, i int) int {
+	r, c := i/size.Cols, i%size.Cols
+	n := 0
+	for _, d := range directions {
+		// The window may start anywhere up to K-1 steps back from i
+		for back := 0; back < size.K; back++ {
+			startR, startC := r-d[0]*back, c-d[1]*back
+			if inBounds(size, startR, startC) && inBounds(size, startR+d[0]*(size.K-1), startC+d[1]*(size.K-1)) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func inBounds(size Size, r, c int) bool {
+	return r >= 0 && r < size.Rows && c >= 0 && c < size.Cols
+}
+
+// eachWindow calls fn with every run of K cells in a straight line
+func eachWindow(size Size, fn func(cells []int)) {
+	cells := make([]int, size.K)
+	for r := 0; r < size.Rows; r++ {
+		for c := 0; c < size.Cols; c++ {
+			for _, d := range directions {
+				if !inBounds(size, r+d[0]*(size.K-1), c+d[1]*(size.K-1)) {
+					continue
+				}
+				for k := range cells {
+					cells[k] = (r+d[0]*k)*size.Cols + c + d[1]*k
+				}
+				fn(cells)
+			}
+		}
+	}
+}
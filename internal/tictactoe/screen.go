@@ -0,0 +1,144 @@
+package tictactoe
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// screen is a cursor on the board
+type screen struct {
+	r, c int
+}
+
+func newScreen() game.Screen {
+	// Centred once the board arrives and its size is known
+	return screen{r: -1, c: -1}
+}
+
+// Sync centres the cursor if it is off the board, as it is before the
+// first snapshot of a room arrives
+func (sc screen) Sync(v game.View) game.Screen {
+	size := v.State.(State).Size
+	if sc.r < 0 || sc.r >= size.Rows || sc.c < 0 || sc.c >= size.Cols {
+		sc.r, sc.c = size.Rows/2, size.Cols/2
+	}
+	return sc
+}
+
+func (sc screen) Update(v game.View, msg tea.KeyMsg) (game.Screen, game.Action) {
+	s := v.State.(State)
+	switch msg.String() {
+	case "up", "k":
+		if sc.r > 0 {
+			sc.r--
+		}
+	case "down", "j":
+		if sc.r < s.Size.Rows-1 {
+			sc.r++
+		}
+	case "left", "h":
+		if sc.c > 0 {
+			sc.c--
+		}
+	case "right", "l":
+		if sc.c < s.Size.Cols-1 {
+			sc.c++
+		}
+	case " ", "enter":
+		idx := sc.r*s.Size.Cols + sc.c
+		if v.MyTurn() && isEmpty(s.Board[idx]) {
+			return sc, game.Action{Move: strconv.Itoa(idx)}
+		}
+	default:
+		return sc, game.Action{Unhandled: true}
+	}
+	return sc, game.Action{}
+}
+
+func (sc screen) Render(v game.View) string {
+	s := v.State.(State)
+	size := s.Size
+	cellW, cellH := computeCellSize(size, v.Width, v.Height)
+
+	var rows []string
+	for r := 0; r < size.Rows; r++ {
+		var cols []string
+		for c := 0; c < size.Cols; c++ {
+			idx := r*size.Cols + c
+			val := s.Board[idx]
+
+			// Boxed cells when there is room, a compact grid when not
+			style, winStyle, selStyle := styles.Cell, styles.CellWin, styles.CellSelected
+			content := " "
+			if cellW == 0 {
+				style, winStyle, selStyle = styles.CellCompact, styles.CellCompactWin, styles.CellCompactSelected
+				content = styles.Muted.Render("·")
+			} else {
+				style, winStyle, selStyle = style.Width(cellW).Height(cellH), winStyle.Width(cellW).Height(cellH), selStyle.Width(cellW).Height(cellH)
+			}
+
+			for _, w := range s.Line {
+				if idx == w {
+					style = winStyle
+				}
+			}
+			if v.MyTurn() && r == sc.r && c == sc.c {
+				style = selStyle
+			}
+
+			if val == game.X {
+				content = styles.XStyle.Render("X")
+			}
+			if val == game.O {
+				content = styles.OStyle.Render("O")
+			}
+			cols = append(cols, style.Render(content))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cols...))
+	}
+	board := lipgloss.JoinVertical(lipgloss.Center, rows...)
+
+	title := "TICTACTOE"
+	if size != Classic {
+		title = fmt.Sprintf("%dx%d · %d IN A ROW", size.Rows, size.Cols, size.K)
+	}
+	return lipgloss.JoinVertical(lipgloss.Center,
+		styles.Title.Render(title),
+		game.Scoreboard(v),
+		"\n",
+		board,
+		"\n",
+		game.StatusLine(v, s.Turn),
+	)
+}
+
+func (sc screen) Help(v game.View) string {
+	return "Arrows: Move • Space: Place • R: Restart • Q: Quit"
+}
+
+// computeCellSize picks the inner size of a boxed cell so the board fits
+// the terminal, at most the classic 10x5. It returns 0, 0 when even the
+// smallest box won't fit and the compact grid should be used.
+func computeCellSize(size Size, termWidth, termHeight int) (cellW, cellH int) {
+	availW := termWidth - 8
+	availH := termHeight - 14
+
+	// Each box adds a border of one on every side
+	cellH = availH/size.Rows - 2
+	if cellH > 5 {
+		cellH = 5
+	}
+	if w := (availW/size.Cols - 2) / 2; w < cellH {
+		cellH = w
+	}
+	if cellH < 1 {
+		return 0, 0
+	}
+	return cellH * 2, cellH
+}
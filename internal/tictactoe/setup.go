@@ -0,0 +1,47 @@
+package tictactoe
+
+import (
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// setup picks one of the Sizes
+type setup struct {
+	preset int
+}
+
+func newSetup() game.Setup {
+	return setup{}
+}
+
+func (st setup) Update(msg tea.KeyMsg) (game.Setup, tea.Cmd) {
+	switch msg.String() {
+	case "left", "h":
+		st.preset = (st.preset + len(Sizes) - 1) % len(Sizes)
+	case "right", "l":
+		st.preset = (st.preset + 1) % len(Sizes)
+	}
+	return st, nil
+}
+
+func (st setup) View() string {
+	var sizes []string
+	for i, sz := range Sizes {
+		sizes = append(sizes, game.RenderPreset(sz.Name, i == st.preset))
+	}
+	return lipgloss.JoinVertical(lipgloss.Center,
+		"Board:",
+		styles.ListContainer.Width(66).Align(lipgloss.Center).Render(lipgloss.JoinHorizontal(lipgloss.Center, sizes...)),
+	)
+}
+
+func (st setup) Help() string {
+	return "←/→: Board"
+}
+
+func (st setup) Settings() (game.Settings, error) {
+	return game.Settings{Variant: Sizes[st.preset].Size}, nil
+}
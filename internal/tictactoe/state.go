@@ -0,0 +1,105 @@
+package tictactoe
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Game is tic-tac-toe on any of the Sizes. Moves are cell indexes.
+var Game = &game.Game{
+	ID:             "tictactoe",
+	Name:           "Tic Tac Toe",
+	Seats:          [2]string{game.X, game.O},
+	Rules:          rules{},
+	NewScreen:      newScreen,
+	NewSetup:       newSetup,
+	Engine:         engine{},
+	NewSoloSetup:   newSetup,
+	AlternateFirst: true,
+}
+
+// State is a game on a board of any size. Once someone has K in a row,
+// Winner and Line say who and where.
+type State struct {
+	Size   Size     `json:"size"`
+	Board  []string `json:"board"`
+	Turn   string   `json:"turn"` // "X" or "O"
+	Over   bool     `json:"over,omitempty"`
+	Winner string   `json:"winner,omitempty"`
+	Line   []int    `json:"line,omitempty"`
+}
+
+type rules struct{}
+
+// New starts an empty board. variant is a Size, or nil for Classic.
+func (rules) New(variant any) (game.State, error) {
+	size := Classic
+	if v, ok := variant.(Size); ok && v != (Size{}) {
+		size = v
+	}
+	if !size.Valid() {
+		return nil, fmt.Errorf("invalid board: %dx%d with %d in a row", size.Rows, size.Cols, size.K)
+	}
+	return State{Size: size, Board: size.NewBoard(), Turn: game.X}, nil
+}
+
+func (rules) Decode(data []byte) (game.State, error) {
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if !s.Size.Valid() || len(s.Board) != s.Size.Cells() {
+		return nil, fmt.Errorf("stored board doesn't match its size")
+	}
+	return s, nil
+}
+
+func (s State) ToMove() string {
+	return s.Turn
+}
+
+func (s State) Check(seat, move string) error {
+	if s.Over {
+		return fmt.Errorf("game is over")
+	}
+	if seat != s.Turn {
+		return game.ErrNotYourTurn
+	}
+	idx, err := strconv.Atoi(move)
+	if err != nil || idx < 0 || idx >= len(s.Board) {
+		return fmt.Errorf("cell %s is off the board", move)
+	}
+	if !isEmpty(s.Board[idx]) {
+		return fmt.Errorf("cell %d is taken", idx)
+	}
+	return nil
+}
+
+func (s State) Apply(seat, move string) game.State {
+	idx, _ := strconv.Atoi(move)
+	s.Board = append([]string(nil), s.Board...)
+	s.Board[idx] = seat
+	if winner, line := s.Size.CheckWinner(s.Board); winner != "" {
+		s.Over, s.Winner, s.Line = true, winner, line
+	} else if CheckDraw(s.Board) {
+		s.Over = true
+	} else {
+		s.Turn = game.Other(s.Turn)
+	}
+	return s
+}
+
+func (s State) Outcome() game.Outcome {
+	return game.Outcome{Over: s.Over, Winner: s.Winner}
+}
+
+func (s State) Rematch(first string) game.State {
+	return State{Size: s.Size, Board: s.Size.NewBoard(), Turn: first}
+}
+
+func isEmpty(v string) bool {
+	return v == " " || v == ""
+}
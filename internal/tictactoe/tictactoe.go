@@ -1,22 +1,91 @@
+// Package tictactoe holds the rules for m,n,k games: marks are placed on
+// a Rows×Cols board and the first to get K in a row wins. Classic
+// tic-tac-toe is 3,3,3 and Gomoku is 15,15,5.
 package tictactoe
 
-func CheckWinner(b [9]string) (string, []int) {
-	wins := [][]int{
-		{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, // Rows
-		{0, 3, 6}, {1, 4, 7}, {2, 5, 8}, // Cols
-		{0, 4, 8}, {2, 4, 6}, // Diags
+// Size is the board shape and how many in a row win
+type Size struct {
+	Rows int `json:"rows"`
+	Cols int `json:"cols"`
+	K    int `json:"k"`
+}
+
+// Classic is plain 3×3 tic-tac-toe, used when a room doesn't say otherwise
+var Classic = Size{Rows: 3, Cols: 3, K: 3}
+
+// Sizes are the boards offered when creating a room
+var Sizes = []struct {
+	Name string
+	Size Size
+}{
+	{"3×3", Classic},
+	{"4×4, 4 in a row", Size{Rows: 4, Cols: 4, K: 4}},
+	{"Gomoku 15×15", Size{Rows: 15, Cols: 15, K: 5}},
+}
+
+// Valid reports whether s is a playable board
+func (s Size) Valid() bool {
+	return s.Rows >= 3 && s.Cols >= 3 && s.Rows <= 19 && s.Cols <= 19 &&
+		s.K >= 3 && s.K <= s.Rows && s.K <= s.Cols
+}
+
+// Cells is how many squares the board has
+func (s Size) Cells() int {
+	return s.Rows * s.Cols
+}
+
+// NewBoard returns an empty board, cell r,c at index r*Cols+c
+func (s Size) NewBoard() []string {
+	b := make([]string, s.Cells())
+	for i := range b {
+		b[i] = " "
 	}
-	for _, w := range wins {
-		if b[w[0]] != " " && b[w[0]] == b[w[1]] && b[w[1]] == b[w[2]] {
-			return b[w[0]], w
+	return b
+}
+
+// Lines through a cell run in four directions: across, down and both
+// diagonals. Each is a row and column step.
+var directions = [4][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+// CheckWinner returns the winning mark and the cells of its run, or "" and
+// nil. A run longer than K also wins and is returned whole.
+func (s Size) CheckWinner(b []string) (string, []int) {
+	for r := 0; r < s.Rows; r++ {
+		for c := 0; c < s.Cols; c++ {
+			mark := b[r*s.Cols+c]
+			if mark == " " || mark == "" {
+				continue
+			}
+			for _, d := range directions {
+				// Only count from the start of a run
+				if s.at(b, r-d[0], c-d[1]) == mark {
+					continue
+				}
+				var line []int
+				for rr, cc := r, c; s.at(b, rr, cc) == mark; rr, cc = rr+d[0], cc+d[1] {
+					line = append(line, rr*s.Cols+cc)
+				}
+				if len(line) >= s.K {
+					return mark, line
+				}
+			}
 		}
 	}
 	return "", nil
 }
 
-func CheckDraw(b [9]string) bool {
+// at returns the mark on r,c, or "" off the board
+func (s Size) at(b []string, r, c int) string {
+	if r < 0 || r >= s.Rows || c < 0 || c >= s.Cols {
+		return ""
+	}
+	return b[r*s.Cols+c]
+}
+
+// CheckDraw reports whether the board is full
+func CheckDraw(b []string) bool {
 	for _, v := range b {
-		if v == " " {
+		if v == " " || v == "" {
 			return false
 		}
 	}
@@ -0,0 +1,151 @@
+package tictactoe
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// board reads rows of X, O and . into a board of size s
+func board(t *testing.T, s Size, rows ...string) []string {
+	t.Helper()
+	if len(rows) != s.Rows {
+		t.Fatalf("%d rows for a board of %d", len(rows), s.Rows)
+	}
+	b := s.NewBoard()
+	for r, row := range rows {
+		if len(row) != s.Cols {
+			t.Fatalf("row %q is not %d wide", row, s.Cols)
+		}
+		for c, ch := range row {
+			if ch != '.' {
+				b[r*s.Cols+c] = string(ch)
+			}
+		}
+	}
+	return b
+}
+
+func TestCheckWinner(t *testing.T) {
+	four := Size{Rows: 4, Cols: 4, K: 4}
+	gomoku := Size{Rows: 15, Cols: 15, K: 5}
+	wide := Size{Rows: 3, Cols: 6, K: 4}
+
+	// Five across the middle of a Gomoku board, and four that don't win
+	g := make([]string, gomoku.Rows)
+	for r := range g {
+		g[r] = strings.Repeat(".", gomoku.Cols)
+	}
+	g[7] = "...XXXXX......."
+	g[8] = "...OOOO........"
+
+	tests := []struct {
+		name   string
+		size   Size
+		rows   []string
+		winner string
+		line   []int
+	}{
+		{"empty", Classic, []string{"...", "...", "..."}, "", nil},
+		{"row", Classic, []string{"OO.", "XXX", "..."}, "X", []int{3, 4, 5}},
+		{"column", Classic, []string{"OX.", "OX.", "O.X"}, "O", []int{0, 3, 6}},
+		{"diagonal", Classic, []string{"X.O", ".XO", "..X"}, "X", []int{0, 4, 8}},
+		{"anti-diagonal", Classic, []string{"X.O", ".OX", "O.X"}, "O", []int{2, 4, 6}},
+		{"full with no line", Classic, []string{"XOX", "XOO", "OXX"}, "", nil},
+		{"three on 4×4 is not enough", four, []string{"XXX.", "OOO.", "....", "...."}, "", nil},
+		{"four on 4×4", four, []string{"OOO.", "XXXX", "....", "...."}, "X", []int{4, 5, 6, 7}},
+		{"4×4 anti-diagonal", four, []string{"...O", "..O.", ".O..", "O..."}, "O", []int{3, 6, 9, 12}},
+		{"wide board row", wide, []string{"..XXXX", "OOO...", "......"}, "X", []int{2, 3, 4, 5}},
+		{"run longer than K", wide, []string{"OOOOO.", "XXX...", "......"}, "O", []int{0, 1, 2, 3, 4}},
+		{"wide board cut by the edge", wide, []string{"XX....", "....XX", "......"}, "", nil},
+		{"gomoku five", gomoku, g, "X", []int{108, 109, 110, 111, 112}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			winner, line := tt.size.CheckWinner(board(t, tt.size, tt.rows...))
+			if winner != tt.winner || !slices.Equal(line, tt.line) {
+				t.Errorf("CheckWinner = %q %v, want %q %v", winner, line, tt.winner, tt.line)
+			}
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		size Size
+		want bool
+	}{
+		{Classic, true},
+		{Size{Rows: 15, Cols: 15, K: 5}, true},
+		{Size{Rows: 2, Cols: 3, K: 2}, false},
+		{Size{Rows: 3, Cols: 3, K: 4}, false},
+		{Size{Rows: 20, Cols: 20, K: 5}, false},
+		{Size{Rows: 5, Cols: 3, K: 4}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.size.Valid(); got != tt.want {
+			t.Errorf("%+v.Valid() = %v, want %v", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestPlayToAWin(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant any
+		moves   []int
+		winner  string
+	}{
+		{"classic row", nil, []int{0, 3, 1, 4, 2}, game.X},
+		{"classic draw", nil, []int{0, 1, 2, 4, 3, 5, 7, 6, 8}, ""},
+		{"4×4 column", Sizes[1].Size, []int{0, 1, 4, 5, 8, 9, 2, 13}, game.O},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := rules{}.New(tt.variant)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, cell := range tt.moves {
+				if s.Outcome().Over {
+					t.Fatalf("game over before move %d", i+1)
+				}
+				move := strconv.Itoa(cell)
+				if err := s.Check(s.ToMove(), move); err != nil {
+					t.Fatalf("move %d: %v", i+1, err)
+				}
+				s = s.Apply(s.ToMove(), move)
+			}
+			if out := s.Outcome(); !out.Over || out.Winner != tt.winner {
+				t.Errorf("Outcome = %+v, want over with winner %q", out, tt.winner)
+			}
+		})
+	}
+}
+
+func TestCheckRejects(t *testing.T) {
+	s, err := rules{}.New(Sizes[1].Size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s = s.Apply(game.X, "5")
+	tests := []struct {
+		name, seat, move string
+	}{
+		{"out of turn", game.X, "0"},
+		{"taken", game.O, "5"},
+		{"off the board", game.O, "16"},
+		{"not a cell", game.O, "a1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.Check(tt.seat, tt.move); err == nil {
+				t.Errorf("Check(%s, %s) took it", tt.seat, tt.move)
+			}
+		})
+	}
+}
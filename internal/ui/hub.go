@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/config"
+	"github.com/aminshahid573/termplay/internal/db"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+)
+
+// Hub keeps one feed per room, shared by every session watching it, and
+// pushes each change into their programs with tea.Program.Send, as each
+// viewer may see it. Stores that
+// implement db.Watcher push changes to the feed; anything else gets polled
+// once per room at config.SyncInterval instead of once per viewer. The hub
+// also runs the live games of rooms on this server; see JoinLive.
+type Hub struct {
+	store db.Store
+
+	mu    sync.Mutex
+	feeds map[string]*roomFeed
+	lives map[string]*liveRoom
+}
+
+type roomFeed struct {
+	subs map[*tea.Program]string // Viewer's player ID
+	stop func()
+
+	// latest holds the newest room; wake coalesces bursts of updates
+	mu     sync.Mutex
+	latest *db.Room
+	wake   chan struct{}
+}
+
+func NewHub(store db.Store) *Hub {
+	return &Hub{store: store, feeds: make(map[string]*roomFeed), lives: make(map[string]*liveRoom)}
+}
+
+// Subscribe starts sending roomUpdateMsg for code to p, whose player is pid
+func (h *Hub) Subscribe(code, pid string, p *tea.Program) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	f, ok := h.feeds[code]
+	if !ok {
+		f = &roomFeed{subs: make(map[*tea.Program]string), wake: make(chan struct{}, 1)}
+		h.feeds[code] = f
+		f.start(h, code)
+	}
+	f.subs[p] = pid
+}
+
+// Unsubscribe stops updates for code to p; the feed ends with its last viewer
+func (h *Hub) Unsubscribe(code string, p *tea.Program) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	f, ok := h.feeds[code]
+	if !ok {
+		return
+	}
+	delete(f.subs, p)
+	if len(f.subs) == 0 {
+		f.stop()
+		delete(h.feeds, code)
+	}
+}
+
+func (f *roomFeed) start(h *Hub, code string) {
+	done := make(chan struct{})
+	var stopSource func()
+
+	if w, ok := h.store.(db.Watcher); ok {
+		stopSource = w.Watch(code, f.push)
+	} else {
+		stopSource = f.poll(h.store, code, done)
+	}
+
+	go f.fanOut(h, code, done)
+
+	var once sync.Once
+	f.stop = func() {
+		once.Do(func() {
+			stopSource()
+			close(done)
+		})
+	}
+}
+
+// push records the newest room and wakes the fan-out without blocking the
+// writer that triggered it.
+func (f *roomFeed) push(r *db.Room) {
+	f.mu.Lock()
+	f.latest = r
+	f.mu.Unlock()
+	select {
+	case f.wake <- struct{}{}:
+	default:
+	}
+}
+
+// poll is the fallback source for stores that can't push
+func (f *roomFeed) poll(store db.Store, code string, done chan struct{}) func() {
+	go func() {
+		var last []byte
+		gone := false
+		t := time.NewTicker(config.SyncInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+			}
+			r, err := store.GetRoom(code)
+			if errors.Is(err, db.ErrRoomNotFound) {
+				// Tell viewers once; a room made again under the
+				// same code is news, so forget the last one seen
+				if !gone {
+					gone, last = true, nil
+					f.push(nil)
+				}
+				continue
+			}
+			if err != nil {
+				log.Error("Hub poll failed", "code", code, "err", err)
+				continue
+			}
+			// Only wake viewers when something actually changed
+			b, _ := json.Marshal(r)
+			if string(b) == string(last) {
+				continue
+			}
+			last, gone = b, false
+			f.push(r)
+		}
+	}()
+	return func() {}
+}
+
+func (f *roomFeed) fanOut(h *Hub, code string, done chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-f.wake:
+		}
+
+		f.mu.Lock()
+		r := f.latest
+		f.mu.Unlock()
+
+		h.mu.Lock()
+		subs := make(map[*tea.Program]string, len(f.subs))
+		for p, pid := range f.subs {
+			subs[p] = pid
+		}
+		h.mu.Unlock()
+
+		for p, pid := range subs {
+			msg := roomUpdateMsg{}
+			if r != nil {
+				msg = roomUpdateMsg(r.For(pid))
+			}
+			p.Send(msg)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/game"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// liveFrameMsg is the newest frame of the live game in room code
+type liveFrameMsg struct {
+	code  string
+	frame game.Frame
+}
+
+// liveRoom runs the Live game of one room on this server. Its goroutine
+// steps the game at the game's tick and sends every viewer the frame.
+type liveRoom struct {
+	mu   sync.Mutex
+	game game.Live
+	subs map[*tea.Program]string // Viewer's player ID
+	done chan struct{}
+}
+
+// JoinLive starts sending p, whose player is pid, the frames of the live
+// game in room code. The first viewer on this server starts the game.
+func (h *Hub) JoinLive(code, pid string, g *game.Game, p *tea.Program) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	lr, ok := h.lives[code]
+	if !ok {
+		lr = &liveRoom{game: g.NewLive(), subs: make(map[*tea.Program]string), done: make(chan struct{})}
+		h.lives[code] = lr
+		go lr.run(code)
+	}
+	lr.mu.Lock()
+	lr.subs[p] = pid
+	lr.mu.Unlock()
+}
+
+// LiveInput passes a key from pid, called name, to the live game in code
+func (h *Hub) LiveInput(code, pid, name, key string) {
+	h.mu.Lock()
+	lr, ok := h.lives[code]
+	h.mu.Unlock()
+	if !ok {
+		return
+	}
+	lr.mu.Lock()
+	lr.game.Input(pid, name, key)
+	lr.mu.Unlock()
+}
+
+// LeaveLive takes pid out of the live game in code and stops sending p
+// its frames. The game ends with its last viewer.
+func (h *Hub) LeaveLive(code, pid string, p *tea.Program) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	lr, ok := h.lives[code]
+	if !ok {
+		return
+	}
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	lr.game.Leave(pid)
+	delete(lr.subs, p)
+	if len(lr.subs) == 0 {
+		close(lr.done)
+		delete(h.lives, code)
+	}
+}
+
+func (lr *liveRoom) run(code string) {
+	t := time.NewTicker(lr.game.Tick())
+	defer t.Stop()
+	for {
+		select {
+		case <-lr.done:
+			return
+		case <-t.C:
+		}
+
+		lr.mu.Lock()
+		lr.game.Step()
+		msg := liveFrameMsg{code: code, frame: lr.game.Frame()}
+		subs := make([]*tea.Program, 0, len(lr.subs))
+		for p := range lr.subs {
+			subs = append(subs, p)
+		}
+		lr.mu.Unlock()
+
+		for _, p := range subs {
+			p.Send(msg)
+		}
+	}
+}
@@ -1,9 +1,9 @@
 package ui
 
 import (
-	"github.com/aminshahid573/termplay/internal/chess"
 	"github.com/aminshahid573/termplay/internal/db"
-	"github.com/aminshahid573/termplay/internal/snake"
+	"github.com/aminshahid573/termplay/internal/game"
+	"io"
 	"strings"
 	"sync"
 
@@ -24,27 +24,58 @@ const (
 	StateLobby
 	StateGame
 	StateGameSelect
-	StateSnakeGame
+	StateArcade
+	StateAISetup
 )
 
+// Main menu entries
+const (
+	menuCreate   = "Create Room"
+	menuJoin     = "Join with Code"
+	menuPublic   = "Public Rooms"
+	menuComputer = "Play vs Computer"
+	menuQuit     = "Quit"
+)
+
+// menuOptions lists the main menu for g
+func menuOptions(g *game.Game) []string {
+	if g != nil && g.Engine != nil {
+		return []string{menuCreate, menuJoin, menuPublic, menuComputer, menuQuit}
+	}
+	return []string{menuCreate, menuJoin, menuPublic, menuQuit}
+}
+
 const (
 	PopupLeave = iota
 	PopupRestart
+	PopupShare
 )
 
 type CleanupState struct {
 	RoomCode  string
 	IsHost    bool
 	SessionID string
-	Mu        sync.Mutex
+	// Program is set by the server once it has built the session's
+	// tea.Program, so the hub can push room updates into it.
+	Program *tea.Program
+	Mu      sync.Mutex
 }
 
 type Model struct {
 	Width, Height int
-	SessionID     string
-	Err           error
+	// SessionID is this connection's own, for rooms and live games.
+	// PlayerID follows the player's SSH key between connections, for what
+	// is kept for them; it is SessionID when they logged in without one.
+	SessionID string
+	PlayerID  string
+	Err       error
+
+	// Term is the raw session output, used for OSC 52 clipboard writes
+	Term io.Writer
 
 	Cleanup *CleanupState
+	Store   db.Store
+	Hub     *Hub // nil = fall back to polling
 
 	State       SessionState
 	TextInput   textinput.Model
@@ -58,29 +89,46 @@ type Model struct {
 	ListSelectedRow int
 
 	IsPublicCreate bool
-	SelectedGame   string
+	SelectedGame   string     // ID of the game picked in the game menu
+	Setup          game.Setup // Its part of the room settings; nil if it has none
 
 	MyName   string
 	MySide   string
 	RoomCode string
 
-	CursorR int
-	CursorC int
-
-	// Chess State
-	ChessSelected   bool
-	ChessSelRow     int
-	ChessSelCol     int
-	ChessValidMoves map[chess.Pos]bool
-	UseNerdFont     bool
-
-	// Snake State
-	Snake snake.Model
+	// ClockTicking is set while a clockTickMsg is in flight; FlagClaimed is
+	// the room version we last asked the server to call the flag on
+	ClockTicking bool
+	FlagClaimed  int64
+
+	// Solo play against the engine, with no room behind it
+	Solo       bool
+	AILevel    int  // into the engine's Levels
+	AISide     int  // X, O or random
+	AIOnSetup  bool // Keys go to Setup rather than the side picker
+	SoloGame   any  // Variant every round is played on
+	AIThinking bool
+	SoloRounds int // Rounds started, to alternate who opens
+
+	// Single-player games like Snake run on their own
+	Arcade game.Arcade
+
+	// Real-time room games run on the server; we only draw their frames
+	Live  bool
+	Frame game.Frame
+
+	Game      db.Room
+	GameState game.State  // Game.State, decoded
+	Screen    game.Screen // Draws GameState and reads our moves
+	Share     *game.Share // Shown in PopupShare
+}
 
-	Game db.Room
+// selectedGame is the game picked in the game menu
+func (m Model) selectedGame() *game.Game {
+	return game.Lookup(m.SelectedGame)
 }
 
-func InitialModel(s ssh.Session, cleanup *CleanupState) Model {
+func InitialModel(s ssh.Session, cleanup *CleanupState, store db.Store, hub *Hub) Model {
 	// 1. Clean Name Input (Placeholder only)
 	ti := textinput.New()
 	ti.Placeholder = "Enter Name" // Shows when empty
@@ -96,15 +144,38 @@ func InitialModel(s ssh.Session, cleanup *CleanupState) Model {
 	si.CharLimit = 20
 	si.Width = 30
 
-	id := "local"
+	id, pid := "local", ""
+	var term io.Writer
 	if s != nil {
+		term = s
+		id = s.RemoteAddr().String()
 		if key := s.PublicKey(); key != nil {
-			id = gossh.FingerprintSHA256(key)
-		} else {
-			id = s.RemoteAddr().String()
+			pid = safeID(gossh.FingerprintSHA256(key))
 		}
 	}
+	id = safeID(id)
+	if pid == "" {
+		pid = id
+	}
 
+	cleanup.SessionID = id
+
+	return Model{
+		State:       StateNameInput,
+		TextInput:   ti,
+		SearchInput: si,
+		Term:        term,
+		SessionID:   id,
+		PlayerID:    pid,
+		Cleanup:     cleanup,
+		Store:       store,
+		Hub:         hub,
+		MenuIndex:   0,
+	}
+}
+
+// safeID makes id fit to be a database key
+func safeID(id string) string {
 	id = strings.ReplaceAll(id, ":", "_")
 	id = strings.ReplaceAll(id, "/", "_")
 	id = strings.ReplaceAll(id, ".", "_")
@@ -112,22 +183,7 @@ func InitialModel(s ssh.Session, cleanup *CleanupState) Model {
 	id = strings.ReplaceAll(id, "=", "")
 	id = strings.ReplaceAll(id, "[", "")
 	id = strings.ReplaceAll(id, "]", "")
-
-	cleanup.SessionID = id
-
-	return Model{
-		State:           StateNameInput,
-		TextInput:       ti,
-		SearchInput:     si,
-		SessionID:       id,
-		Cleanup:         cleanup,
-		MenuIndex:       0,
-		CursorR:         1,
-		CursorC:         1,
-		ChessValidMoves: make(map[chess.Pos]bool),
-		UseNerdFont:     true,
-		Game:            db.Room{Board: [9]string{" ", " ", " ", " ", " ", " ", " ", " ", " "}},
-	}
+	return id
 }
 
 func (m Model) Init() tea.Cmd {
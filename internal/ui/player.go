@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"github.com/aminshahid573/termplay/internal/db"
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// keeper keeps an arcade player's scores and saved items in the store under
+// their player ID, so they follow the player's SSH key from one login to
+// the next
+type keeper struct {
+	store     db.Store
+	pid, name string
+}
+
+func (k keeper) Submit(board string, score int, replay string) error {
+	return k.store.SubmitScore(board, k.pid, k.name, score, replay)
+}
+
+func (k keeper) Leaderboard(board string, n int) (game.Leaderboard, error) {
+	return k.store.GetLeaderboard(board, k.pid, n)
+}
+
+func (k keeper) Save(kind, name, data string) error {
+	return k.store.SaveItem(k.pid, kind, name, data)
+}
+
+func (k keeper) Load(kind string) (map[string]string, error) {
+	return k.store.GetItems(k.pid, kind)
+}
+
+func (k keeper) Delete(kind, name string) error {
+	return k.store.DeleteItem(k.pid, kind, name)
+}
+
+// player is what the store keeps for the arcade player, empty without one
+func (m Model) player() game.Player {
+	if m.Store == nil {
+		return game.Player{}
+	}
+	k := keeper{store: m.Store, pid: m.PlayerID, name: m.MyName}
+	return game.Player{Scores: k, Library: k}
+}
@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/aminshahid573/termplay/internal/db"
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Solo games against the engine live only in the session's model. They
+// reuse the room-based game screen with a db.Room that is never stored.
+
+// engineMoveMsg carries the computer's reply. version is the Game.Version
+// it was searched from, so stale replies can be dropped.
+type engineMoveMsg struct {
+	move    string
+	version int64
+}
+
+// aiSides are the seats the player can pick; the last one is random
+func aiSides(g *game.Game) []string {
+	return []string{g.Seats[0], g.Seats[1], "Random"}
+}
+
+func updateAISetup(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	g := m.selectedGame()
+	levels, sides := g.Engine.Levels(), aiSides(g)
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch k := msg.String(); {
+		case k == "tab" && m.Setup != nil:
+			m.AIOnSetup = !m.AIOnSetup
+			return m, nil
+		case m.AIOnSetup && k != "enter" && k != "esc":
+			var cmd tea.Cmd
+			m.Setup, cmd = m.Setup.Update(msg)
+			return m, cmd
+		}
+		switch msg.String() {
+		case "up", "k":
+			if m.AILevel > 0 {
+				m.AILevel--
+			}
+		case "down", "j":
+			if m.AILevel < len(levels)-1 {
+				m.AILevel++
+			}
+		case "left", "h":
+			m.AISide = (m.AISide + len(sides) - 1) % len(sides)
+		case "right", "l":
+			m.AISide = (m.AISide + 1) % len(sides)
+		case "enter":
+			return m.startSolo()
+		case "esc":
+			m.State = StateMenu
+		}
+	}
+	return m, nil
+}
+
+// startSolo seats the player as picked in the setup screen and starts the
+// first round with a clean score
+func (m Model) startSolo() (Model, tea.Cmd) {
+	g := m.selectedGame()
+	side := m.AISide
+	if side == 2 {
+		side = rand.Intn(2)
+	}
+	bot := "Computer (" + g.Engine.Levels()[m.AILevel].Name + ")"
+	var variant any
+	if m.Setup != nil {
+		settings, err := m.Setup.Settings()
+		if err != nil {
+			m.Err = err
+			return m, nil
+		}
+		variant = settings.Variant
+	}
+
+	m.Game = db.Room{
+		GameType:   g.ID,
+		Spectators: make(map[string]string),
+		Version:    m.Game.Version + 1,
+	}
+	if side == 0 {
+		m.MySide = game.X
+		m.Game.PlayerXName, m.Game.PlayerOName = m.MyName, bot
+	} else {
+		m.MySide = game.O
+		m.Game.PlayerXName, m.Game.PlayerOName = bot, m.MyName
+	}
+
+	m.Solo = true
+	m.SoloGame = variant
+	m.SoloRounds = 0
+	m.RoomCode = ""
+	m.Err = nil
+	m.Screen = g.NewScreen()
+	m.State = StateGame
+	return m.restartSolo()
+}
+
+// restartSolo starts a new round with the same seats, variant and score.
+// Games that ask for it alternate who opens between rounds.
+func (m Model) restartSolo() (Model, tea.Cmd) {
+	g := m.Game.Game()
+	state, err := g.Rules.New(m.SoloGame)
+	if err != nil {
+		m.Err = err
+		return m, nil
+	}
+	first := game.X
+	if g.AlternateFirst && m.SoloRounds%2 == 1 {
+		first = game.O
+	}
+
+	m.Game.Status = "playing"
+	m.Game.Winner = ""
+	m.Game.Reason = ""
+	m.Game.StartedAt = time.Now().Unix()
+	m.SoloRounds++
+	return m.setSoloState(state.Rematch(first))
+}
+
+// setSoloState moves the local game on to state, then hands the turn to
+// the engine if it is due
+func (m Model) setSoloState(state game.State) (Model, tea.Cmd) {
+	if err := m.Game.SetState(state); err != nil {
+		m.Err = err
+		return m, nil
+	}
+	m.GameState = state
+	// Bumped on every move so engine replies can be matched up
+	m.Game.Version++
+	m.Screen = m.Screen.Sync(m.gameView())
+	m.AIThinking = false
+	return m, m.engineCmd()
+}
+
+// playMove makes a move for the player, in the room or in a solo game
+func (m Model) playMove(move string) (Model, tea.Cmd) {
+	if !m.Solo {
+		return m, submitMoveCmd(m.Store, m.RoomCode, m.SessionID, move, m.Game.Version)
+	}
+	return m.playSolo(m.MySide, move)
+}
+
+// playSolo applies seat's move to the local game, checked as the server
+// would
+func (m Model) playSolo(seat, move string) (Model, tea.Cmd) {
+	if m.Game.Status != "playing" || m.GameState.Check(seat, move) != nil {
+		return m, nil
+	}
+	return m.setSoloState(m.GameState.Apply(seat, move))
+}
+
+// engineDelay is how long the engine waits before it starts thinking. An
+// instant reply to an easy position reads as a glitch.
+const engineDelay = 300 * time.Millisecond
+
+// engineCmd searches for the engine's move in the background, so the
+// Bubble Tea loop keeps running while it thinks
+func (m *Model) engineCmd() tea.Cmd {
+	if !m.Solo || m.Game.Status != "playing" || m.GameState.ToMove() == m.MySide {
+		return nil
+	}
+	engine := m.Game.Game().Engine
+	version, state, lvl := m.Game.Version, m.GameState, engine.Levels()[m.AILevel]
+	m.AIThinking = true
+	return tea.Tick(engineDelay, func(time.Time) tea.Msg {
+		move, ok := engine.Move(context.Background(), state, lvl)
+		if !ok {
+			return nil
+		}
+		return engineMoveMsg{move: move, version: version}
+	})
+}
+
+func renderAISetup(m Model) string {
+	g := m.selectedGame()
+	var levels []string
+	for i, lvl := range g.Engine.Levels() {
+		if i == m.AILevel {
+			levels = append(levels, styles.ItemFocused.Render(" "+lvl.Name+" "))
+		} else {
+			levels = append(levels, styles.ItemBlurred.Render(" "+lvl.Name+" "))
+		}
+	}
+
+	var sides []string
+	for i, side := range aiSides(g) {
+		if i == m.AISide {
+			sides = append(sides, styles.ItemFocused.Render(side))
+		} else {
+			sides = append(sides, styles.ItemBlurred.Render(side))
+		}
+	}
+
+	view := lipgloss.JoinVertical(lipgloss.Center,
+		styles.Title.Render("PLAY VS COMPUTER"),
+		"Strength:",
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, levels...),
+		"",
+		"Play as:",
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Center, sides...),
+	)
+	if m.Setup != nil {
+		view = lipgloss.JoinVertical(lipgloss.Center, view, "", m.Setup.View())
+	}
+	if m.Err != nil {
+		view = lipgloss.JoinVertical(lipgloss.Center, view, styles.Err.Render(m.Err.Error()))
+	}
+	return view
+}
@@ -1,18 +1,21 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"strings"
 	"time"
 
-	"github.com/aminshahid573/termplay/internal/chess"
+	"github.com/aminshahid573/termplay/internal/config"
 	"github.com/aminshahid573/termplay/internal/db"
-	"github.com/aminshahid573/termplay/internal/snake"
+	"github.com/aminshahid573/termplay/internal/game"
 
+	"github.com/aymanbagabas/go-osc52/v2"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/log"
+	"io"
 	"os"
 )
 
@@ -25,6 +28,7 @@ func init() {
 type roomUpdateMsg db.Room
 type roomsFetchedMsg []db.Room
 type errMsg error
+type clockTickMsg struct{}
 type pollErrorMsg error
 
 type roomCreatedMsg struct {
@@ -42,33 +46,55 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// 1. Handle background polling (Highest Priority, Non-Blocking)
 	if roomMsg, ok := msg.(roomUpdateMsg); ok {
+		// Late update for a room we already left
+		if m.RoomCode == "" {
+			return m, nil
+		}
 		m.Game = db.Room(roomMsg)
+		m.GameState = m.Game.GameState()
+		if m.Screen != nil && m.GameState != nil {
+			m.Screen = m.Screen.Sync(m.gameView())
+		}
 		// Auto-transition from Lobby to Game
 		if m.State == StateLobby && m.Game.PlayerO != "" {
 			m.State = StateGame
 		}
 		// Room deleted?
 		if m.Game.PlayerX == "" {
+			m.unwatchRoom()
 			m.Err = fmt.Errorf("Room closed by host")
 			m.State = StateMenu
 			m.RoomCode = ""
+			m.Live = false
 			m.Busy = false
 			return m, nil
 		}
-		return m, pollCmd(m.RoomCode)
+		return m, tea.Batch(m.nextPollCmd(), m.clockCmd())
+	}
+
+	if frame, ok := msg.(liveFrameMsg); ok {
+		// Frames keep coming for a moment after we leave
+		if m.Live && frame.code == m.RoomCode {
+			m.Frame = frame.frame
+		}
+		return m, nil
 	}
 
 	// 2. Handle Polling Errors
 	if err, ok := msg.(pollErrorMsg); ok {
+		if m.RoomCode == "" {
+			return m, nil
+		}
 		m.Err = err
 		// Retry polling after delay
-		return m, pollCmd(m.RoomCode)
+		return m, m.nextPollCmd()
 	}
 
 	// 3. Handle Async DB Results
 	switch msg := msg.(type) {
 	case roomCreatedMsg:
 		m.Busy = false
+		m.Err = nil
 		m.RoomCode = msg.code
 		m.MySide = "X"
 
@@ -77,19 +103,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Cleanup.IsHost = true
 		m.Cleanup.Mu.Unlock()
 
-		if msg.gameType == "chess" {
-			m.CursorR = 7 // White Pieces (Rank 1)
-			m.CursorC = 4 // King File
-		} else {
-			m.CursorR = 1 // Middle of 3x3
-			m.CursorC = 1
-		}
-
+		m.startScreen(msg.gameType)
 		m.State = StateLobby
-		return m, pollCmd(msg.code)
+		if m.Live {
+			// Players come and go in a live game, so there is no one to wait for
+			m.State = StateGame
+			m.joinLive(msg.code, msg.gameType)
+		}
+		return m, m.watchRoomCmd(msg.code)
 
 	case roomJoinedMsg:
 		m.Busy = false
+		m.Err = nil
 		m.RoomCode = msg.code
 		m.MySide = msg.side
 
@@ -98,123 +123,75 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Cleanup.IsHost = (msg.side == "X")
 		m.Cleanup.Mu.Unlock()
 
-		if msg.gameType == "chess" {
-			// If Black ("O"), we want Rank 8 (Index 0)
-			if msg.side == "O" {
-				m.CursorR = 0
-				m.CursorC = 4
-			} else {
-				// White or Spectator (Rank 1 -> Index 7)
-				m.CursorR = 7
-				m.CursorC = 4
-			}
-		} else {
-			m.CursorR = 1
-			m.CursorC = 1
-		}
-
+		m.startScreen(msg.gameType)
 		m.State = StateGame
-		return m, pollCmd(msg.code)
+		if m.Live {
+			m.joinLive(msg.code, msg.gameType)
+		}
+		return m, m.watchRoomCmd(msg.code)
 
 	case errMsg:
 		m.Busy = false
 		m.Err = msg
 		// Stay in current state, allow retry
 		return m, nil
+
+	case clockTickMsg:
+		m.ClockTicking = false
+		return m, m.clockCmd()
+
+	case engineMoveMsg:
+		// Drop replies to a game we left or a position that moved on
+		if !m.Solo || msg.version != m.Game.Version {
+			return m, nil
+		}
+		return m.playSolo(m.GameState.ToMove(), msg.move)
 	}
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.Width = msg.Width
 		m.Height = msg.Height
-		m.Snake.TermW = msg.Width
-		m.Snake.TermH = msg.Height
 	case tea.KeyMsg:
 		if msg.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
 	}
 
-	// Handle snake game ticks and input
-	if m.State == StateSnakeGame {
-		switch msg := msg.(type) {
-		case snake.TickMsg:
-			m.Snake, cmd = m.Snake.Update(msg)
-			if m.Snake.WantsQuit {
-				m.Snake.WantsQuit = false
-				m.State = StateGameSelect
-				m.MenuIndex = 0
-				return m, nil
-			}
-			return m, cmd
-		case tea.KeyMsg:
-			m.Snake, cmd = m.Snake.Update(msg)
-			if m.Snake.WantsQuit {
-				m.Snake.WantsQuit = false
-				m.State = StateGameSelect
-				m.MenuIndex = 0
-				return m, nil
-			}
-			return m, cmd
+	// Single-player games get every message, ticks included
+	if m.State == StateArcade {
+		m.Arcade, cmd = m.Arcade.Update(msg)
+		if m.Arcade.Done() {
+			m.Arcade = nil
+			m.State = StateGameSelect
+			m.MenuIndex = 0
+			return m, nil
 		}
-		return m, nil
+		return m, cmd
 	}
 
 	// Global Popup Handler
 	if m.PopupActive {
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
+			if m.PopupType == PopupShare {
+				// Any key closes the shared text
+				m.PopupActive = false
+				return m, nil
+			}
 			if m.PopupType == PopupRestart {
 				switch msg.String() {
-				case "1":
-					// Random
-					next := "X"
-					if m.Game.GameType == "chess" {
-						next = "White"
-						if rand.Intn(2) == 0 {
-							next = "Black"
-						}
-					} else {
-						if rand.Intn(2) == 0 {
-							next = "O"
-						}
-					}
-					m.PopupActive = false
-					return m, func() tea.Msg {
-						db.RestartGame(m.RoomCode, next)
-						return nil
+				case "1", "2":
+					// Random, or the winner starts; a draw is random too
+					next := game.X
+					if rand.Intn(2) == 0 {
+						next = game.O
 					}
-				case "2":
-					// Winner
-					next := m.Game.Winner
-					if next == "" {
-						// If draw, Random
-						if m.Game.GameType == "chess" {
-							next = "White"
-							if rand.Intn(2) == 0 {
-								next = "Black"
-							}
-						} else {
-							next = "X"
-							if rand.Intn(2) == 0 {
-								next = "O"
-							}
-						}
-					} else {
-						// Map winner to turn
-						if m.Game.GameType == "chess" {
-							// Winner is White/Black
-							next = m.Game.Winner
-						} else {
-							// Winner is X/O
-							next = m.Game.Winner
-						}
+					if msg.String() == "2" && m.Game.Winner != "" {
+						next = m.Game.Winner
 					}
 					m.PopupActive = false
-					return m, func() tea.Msg {
-						db.RestartGame(m.RoomCode, next)
-						return nil
-					}
+					return m, restartGameCmd(m.Store, m.RoomCode, m.SessionID, next, m.Game.Version)
 				case "esc":
 					m.PopupActive = false
 				}
@@ -225,12 +202,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					// Confirm Leave
 					isHost := (m.MySide == "X")
 					if m.RoomCode != "" {
-						db.LeaveRoom(m.RoomCode, m.SessionID, isHost)
+						m.unwatchRoom()
+						m.Store.LeaveRoom(m.RoomCode, m.SessionID, isHost)
 					}
 					m.PopupActive = false
 					m.State = StateMenu
 					m.Err = nil
 					m.RoomCode = "" // Clear room code on exit
+					m.Solo = false
+					m.Live = false
 					return m, nil
 				case "n", "esc":
 					m.PopupActive = false
@@ -254,9 +234,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m, cmd = updateCodeInput(m, msg)
 	case StatePublicList:
 		m, cmd = updatePublicList(m, msg)
+	case StateAISetup:
+		m, cmd = updateAISetup(m, msg)
 	case StateLobby, StateGame:
 		m, cmd = updateGame(m, msg)
-	case StateSnakeGame:
+	case StateArcade:
 		// Handled above before popup handler
 	}
 
@@ -284,6 +266,7 @@ func updateName(m Model, msg tea.Msg) (Model, tea.Cmd) {
 
 // --- 1.5 Game Selection Logic ---
 func updateGameSelect(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	games := game.All()
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -292,27 +275,21 @@ func updateGameSelect(m Model, msg tea.Msg) (Model, tea.Cmd) {
 				m.MenuIndex--
 			}
 		case "down", "j":
-			if m.MenuIndex < 2 { // 0: TicTacToe, 1: Chess, 2: Snake
+			if m.MenuIndex < len(games)-1 {
 				m.MenuIndex++
 			}
 		case "enter":
-			switch m.MenuIndex {
-			case 0:
-				m.SelectedGame = "tictactoe"
-				m.State = StateMenu
-				m.MenuIndex = 0
-			case 1:
-				m.SelectedGame = "chess"
-				m.State = StateMenu
-				m.MenuIndex = 0
-			case 2:
-				// Snake is single-player — go directly to snake game
-				m.Snake = snake.InitialModel()
-				m.Snake.TermW = m.Width
-				m.Snake.TermH = m.Height
-				m.State = StateSnakeGame
-				return m, snake.TickCmd()
-			}
+			g := games[m.MenuIndex]
+			if g.NewArcade != nil {
+				// Single-player — go directly to the game
+				var cmd tea.Cmd
+				m.Arcade, cmd = g.NewArcade(m.Width, m.Height, m.player())
+				m.State = StateArcade
+				return m, cmd
+			}
+			m.SelectedGame = g.ID
+			m.State = StateMenu
+			m.MenuIndex = 0
 			return m, nil
 		}
 	}
@@ -321,6 +298,7 @@ func updateGameSelect(m Model, msg tea.Msg) (Model, tea.Cmd) {
 
 // --- 2. Main Menu Logic ---
 func updateMenu(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	opts := menuOptions(m.selectedGame())
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -329,25 +307,40 @@ func updateMenu(m Model, msg tea.Msg) (Model, tea.Cmd) {
 				m.MenuIndex--
 			}
 		case "down", "j":
-			if m.MenuIndex < 3 {
+			if m.MenuIndex < len(opts)-1 {
 				m.MenuIndex++
 			}
 		case "enter":
-			if m.MenuIndex == 0 { // Create Room
+			switch opts[m.MenuIndex] {
+			case menuCreate:
 				m.State = StateCreateConfig
 				m.IsPublicCreate = false // default to private
-			} else if m.MenuIndex == 1 { // Join via Code
+				m.Setup = nil
+				if g := m.selectedGame(); g.NewSetup != nil {
+					m.Setup = g.NewSetup()
+				}
+				m.Err = nil
+			case menuJoin:
 				m.State = StateInputCode
 				m.TextInput.Placeholder = "4-Digit Code"
 				m.TextInput.SetValue("")
 				m.TextInput.Focus()
 				return m, textinput.Blink
-			} else if m.MenuIndex == 2 { // Public Rooms List
+			case menuPublic:
 				m.State = StatePublicList
 				m.SearchInput.Focus()
 				m.ListSelectedRow = 0 // Reset selection to top
-				return m, fetchPublicRoomsCmd()
-			} else { // Quit
+				return m, fetchPublicRoomsCmd(m.Store)
+			case menuComputer:
+				m.State = StateAISetup
+				m.AILevel = 0 // Level lists differ per game
+				m.AIOnSetup = false
+				m.Setup = nil
+				if g := m.selectedGame(); g.NewSoloSetup != nil {
+					m.Setup = g.NewSoloSetup()
+				}
+				m.Err = nil
+			default: // Quit
 				return m, tea.Quit
 			}
 		}
@@ -357,25 +350,43 @@ func updateMenu(m Model, msg tea.Msg) (Model, tea.Cmd) {
 
 // --- 3. Create Room Configuration ---
 func updateCreateConfig(m Model, msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "up", "down", "k", "j":
-			m.IsPublicCreate = !m.IsPublicCreate
 		case "enter":
 			if m.Busy {
 				return m, nil
 			}
-			m.Busy = true
 			code := generateCode()
-			// Use SelectedGame
-			gameType := m.SelectedGame
-			if gameType == "" {
-				gameType = "tictactoe"
-			} // Fallback
-			return m, createRoomCmd(code, m.SessionID, m.MyName, m.IsPublicCreate, gameType)
+			opts := db.RoomOptions{Public: m.IsPublicCreate, GameType: m.SelectedGame}
+			if m.Setup != nil {
+				settings, err := m.Setup.Settings()
+				if err != nil {
+					m.Err = err
+					return m, nil
+				}
+				opts.Variant, opts.TimeControl = settings.Variant, settings.TimeControl
+			}
+			m.Busy = true
+			return m, createRoomCmd(m.Store, code, m.SessionID, m.MyName, opts)
 		case "esc":
 			m.State = StateMenu
+			m.Err = nil
+			return m, nil
+		case "up", "down":
+			m.IsPublicCreate = !m.IsPublicCreate
+			return m, nil
+		}
+
+		// The rest belongs to the game's own settings
+		if m.Setup != nil {
+			m.Setup, cmd = m.Setup.Update(msg)
+			return m, cmd
+		}
+		switch msg.String() {
+		case "k", "j":
+			m.IsPublicCreate = !m.IsPublicCreate
 		}
 	}
 	return m, nil
@@ -392,7 +403,7 @@ func updateCodeInput(m Model, msg tea.Msg) (Model, tea.Cmd) {
 			}
 			m.Busy = true
 			code := strings.ToUpper(m.TextInput.Value())
-			return m, joinRoomCmd(code, m.SessionID, m.MyName)
+			return m, joinRoomCmd(m.Store, code, m.SessionID, m.MyName)
 		}
 		if msg.Type == tea.KeyEsc {
 			m.State = StateMenu
@@ -414,7 +425,7 @@ func updatePublicList(m Model, msg tea.Msg) (Model, tea.Cmd) {
 		for _, r := range m.PublicRooms {
 			// Show all if filter empty, otherwise match
 			if filter == "" || strings.Contains(r.Code, filter) || strings.Contains(strings.ToUpper(r.PlayerXName), filter) {
-				if r.PlayerO == "" {
+				if r.Open() {
 					open = append(open, r)
 				} else {
 					full = append(full, r)
@@ -452,7 +463,7 @@ func updatePublicList(m Model, msg tea.Msg) (Model, tea.Cmd) {
 				}
 				sel := list[m.ListSelectedRow]
 				m.Busy = true
-				return m, joinRoomCmd(sel.Code, m.SessionID, m.MyName)
+				return m, joinRoomCmd(m.Store, sel.Code, m.SessionID, m.MyName)
 			}
 		}
 	}
@@ -461,276 +472,295 @@ func updatePublicList(m Model, msg tea.Msg) (Model, tea.Cmd) {
 }
 
 func updateGame(m Model, msg tea.Msg) (Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		if msg.String() == "q" {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	// Any key dismisses the last rejected-move message
+	m.Err = nil
+	if m.Live {
+		return updateLive(m, key)
+	}
+	if m.Screen == nil || m.GameState == nil {
+		// Nothing to play yet, or a game this server doesn't host
+		if key.String() == "q" || key.String() == "esc" {
 			m.PopupActive = true
 			m.PopupType = PopupLeave
-			return m, nil
 		}
-		if msg.String() == "esc" {
-			if m.Game.GameType == "chess" && m.ChessSelected {
-				m.ChessSelected = false
-				m.ChessValidMoves = make(map[chess.Pos]bool)
-				return m, nil
-			}
+		return m, nil
+	}
+
+	// A screen's own popup takes every key until it is done
+	if modal, ok := m.Screen.(game.Modal); !ok || modal.Modal(m.gameView()) == "" {
+		if key.String() == "q" {
 			m.PopupActive = true
 			m.PopupType = PopupLeave
 			return m, nil
 		}
-		if m.Game.Status == "finished" {
-			if msg.String() == "r" {
-				if m.MySide == "Spectator" {
-					return m, nil
-				}
-				m.PopupActive = true
-				m.PopupType = PopupRestart
+		if m.Game.Status == "finished" && key.String() == "r" {
+			if m.MySide == "Spectator" {
 				return m, nil
 			}
+			if m.Solo {
+				// No one to agree with; just start the next round
+				return m.restartSolo()
+			}
+			m.PopupActive = true
+			m.PopupType = PopupRestart
 			return m, nil
 		}
-		if m.Game.Status == "waiting" {
-			return m, nil
-		}
+	}
 
-		if m.Game.GameType == "chess" {
-			// Handle Chess Input
-			return updateChessInput(m, msg)
-		} else {
-			// Handle TicTacToe Input
-			switch msg.String() {
-			case "up", "k":
-				if m.CursorR > 0 {
-					m.CursorR--
-				}
-			case "down", "j":
-				if m.CursorR < 2 {
-					m.CursorR++
-				}
-			case "left", "h":
-				if m.CursorC > 0 {
-					m.CursorC--
-				}
-			case "right", "l":
-				if m.CursorC < 2 {
-					m.CursorC++
-				}
-			case " ", "enter":
-				if m.MySide == "Spectator" {
-					return m, nil
-				}
-				idx := m.CursorR*3 + m.CursorC
-				if m.Game.Turn == m.MySide && m.Game.Board[idx] == " " {
-					return m, func() tea.Msg {
-						db.UpdateMove(m.RoomCode, m.SessionID, idx, m.Game)
-						return nil
-					}
-				}
-			}
+	var action game.Action
+	m.Screen, action = m.Screen.Update(m.gameView(), key)
+	switch {
+	case action.Unhandled && key.String() == "esc":
+		m.PopupActive = true
+		m.PopupType = PopupLeave
+	case action.Share != nil:
+		m.Share = action.Share
+		m.PopupActive = true
+		m.PopupType = PopupShare
+		return m, copyCmd(m.Term, action.Share.Text)
+	case action.Move != "":
+		return m.playMove(action.Move)
+	}
+	return m, nil
+}
+
+// updateLive passes keys to the live game on the server, all but the ones
+// to leave the room
+func updateLive(m Model, key tea.KeyMsg) (Model, tea.Cmd) {
+	switch key.String() {
+	case "q", "esc":
+		m.PopupActive = true
+		m.PopupType = PopupLeave
+	default:
+		if m.usingHub() {
+			m.Hub.LiveInput(m.RoomCode, m.SessionID, m.MyName, key.String())
 		}
 	}
 	return m, nil
 }
 
-// updateChessInput handles chess specific keys
-func updateChessInput(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
-	// Spectators cannot move
-	if m.MySide == "Spectator" {
-		return m, nil
+// startScreen sets up the screen for a room of gameType. Its state comes
+// with the first snapshot of the room, or for a live game its first frame.
+func (m *Model) startScreen(gameType string) {
+	m.GameState = nil
+	m.Screen = nil
+	m.Frame = nil
+	g := game.Lookup(gameType)
+	m.Live = g != nil && g.NewLive != nil
+	if g != nil && g.NewScreen != nil {
+		m.Screen = g.NewScreen()
 	}
+}
 
-	// Turn Check: "White" vs "Black"
-	// MySide is "X" (Host) or "O" (Guest).
-	// Host is White, Guest is Black.
-	isMyTurn := false
-	if m.MySide == "X" && m.Game.Turn == "White" {
-		isMyTurn = true
+// joinLive starts the frames of the live game in room code
+func (m Model) joinLive(code, gameType string) {
+	if m.usingHub() {
+		m.Hub.JoinLive(code, m.SessionID, game.Lookup(gameType), m.program())
 	}
-	if m.MySide == "O" && m.Game.Turn == "Black" {
-		isMyTurn = true
+}
+
+// gameView is what the screen needs to draw the current game
+func (m Model) gameView() game.View {
+	info := m.Game.Info()
+	info.Thinking = m.AIThinking
+	seat := m.MySide
+	if seat == "Spectator" {
+		seat = ""
+	}
+	return game.View{
+		Game:   m.Game.Game(),
+		State:  m.GameState,
+		Info:   info,
+		Seat:   seat,
+		Width:  m.Width,
+		Height: m.Height,
 	}
+}
 
-	// Turn enforcement only on Enter/Space
-	if (msg.String() == "enter" || msg.String() == " ") && !isMyTurn {
-		return m, nil
+// program returns the session's tea.Program once the server has set it
+func (m Model) program() *tea.Program {
+	if m.Cleanup == nil {
+		return nil
 	}
+	m.Cleanup.Mu.Lock()
+	defer m.Cleanup.Mu.Unlock()
+	return m.Cleanup.Program
+}
 
-	isFlipped := (m.MySide == "O")
+func (m Model) usingHub() bool {
+	return m.Hub != nil && m.program() != nil
+}
 
-	switch msg.String() {
-	case "up", "k":
-		if isFlipped {
-			if m.CursorR < 7 {
-				m.CursorR++
-			}
-		} else {
-			if m.CursorR > 0 {
-				m.CursorR--
-			}
-		}
-	case "down", "j":
-		if isFlipped {
-			if m.CursorR > 0 {
-				m.CursorR--
-			}
-		} else {
-			if m.CursorR < 7 {
-				m.CursorR++
-			}
-		}
-	case "left", "h":
-		if isFlipped {
-			if m.CursorC < 7 {
-				m.CursorC++
-			}
-		} else {
-			if m.CursorC > 0 {
-				m.CursorC--
-			}
-		}
-	case "right", "l":
-		if isFlipped {
-			if m.CursorC > 0 {
-				m.CursorC--
-			}
-		} else {
-			if m.CursorC < 7 {
-				m.CursorC++
-			}
-		}
-	case "f":
-		m.UseNerdFont = !m.UseNerdFont
-		return m, nil
+// watchRoomCmd starts room updates for code: pushed by the hub when the
+// session has one, polled otherwise.
+func (m Model) watchRoomCmd(code string) tea.Cmd {
+	if !m.usingHub() {
+		return pollCmd(m.Store, code, m.SessionID)
+	}
+	m.Hub.Subscribe(code, m.SessionID, m.program())
+	// The hub only sends changes, so read the current state once now
+	store, pid := m.Store, m.SessionID
+	return func() tea.Msg {
+		return fetchRoom(store, code, pid)
+	}
+}
 
-	case "enter", " ":
-		log.Info("Key pressed", "key", msg.String())
-		log.Info("Turn check", "mySide", m.MySide, "turn", m.Game.Turn, "isMyTurn", isMyTurn)
-		if !isMyTurn {
-			return m, nil
+func (m Model) unwatchRoom() {
+	if m.usingHub() && m.RoomCode != "" {
+		m.Hub.Unsubscribe(m.RoomCode, m.program())
+		if m.Live {
+			m.Hub.LeaveLive(m.RoomCode, m.SessionID, m.program())
 		}
+	}
+}
 
-		// Chess Move Logic
-		if m.ChessSelected {
-			// If clicking same piece -> deselect
-			if m.CursorR == m.ChessSelRow && m.CursorC == m.ChessSelCol {
-				m.ChessSelected = false
-				m.ChessValidMoves = make(map[chess.Pos]bool)
-				return m, nil
-			}
-
-			// If valid move
-			if m.ChessValidMoves[chess.Pos{Row: m.CursorR, Col: m.CursorC}] {
-				log.Info("Executing move", "from", m.ChessSelRow, m.ChessSelCol, "to", m.CursorR, m.CursorC)
-				// Execute Move
-				newState := chess.ApplyMove(m.Game.ChessState, chess.Pos{Row: m.ChessSelRow, Col: m.ChessSelCol}, chess.Pos{Row: m.CursorR, Col: m.CursorC}, "Q")
-
-				// Clear selection
-				m.ChessSelected = false
-				m.ChessValidMoves = make(map[chess.Pos]bool)
-
-				return m, func() tea.Msg {
-					err := db.UpdateChessState(m.RoomCode, newState)
-					if err != nil {
-						log.Error("UpdateChessState failed", "err", err)
-						return errMsg(fmt.Errorf("move failed: %v", err))
-					}
-					return nil
-				}
-			} else {
-				log.Info("Invalid move attempted", "target", m.CursorR, m.CursorC)
-			}
-
-			// If clicking another friendly piece -> select that instead
-			p := m.Game.ChessState.Board[m.CursorR][m.CursorC]
-			if !p.IsEmpty() {
-				// Check color
-				isWhite := p.IsWhite
-				myColorWhite := (m.MySide == "X")
-				if isWhite == myColorWhite {
-					log.Info("Switching selection", "to", m.CursorR, m.CursorC)
-					// Select this one
-					m.ChessSelected = true
-					m.ChessSelRow = m.CursorR
-					m.ChessSelCol = m.CursorC
-					// Calc moves
-					m.ChessValidMoves = chess.GetLegalMoves(m.Game.ChessState, m.CursorR, m.CursorC)
-					log.Info("Legal moves calculated", "count", len(m.ChessValidMoves))
-					return m, nil
-				}
-			}
-
-			// Clicked empty/invalid -> deselect
-			m.ChessSelected = false
-			m.ChessValidMoves = make(map[chess.Pos]bool)
-
-		} else {
-			// Selecting
-			p := m.Game.ChessState.Board[m.CursorR][m.CursorC]
-			if !p.IsEmpty() {
-				// Check color
-				isWhite := p.IsWhite
-				myColorWhite := (m.MySide == "X")
-				if isWhite == myColorWhite {
-					log.Info("Selected piece", "row", m.CursorR, "col", m.CursorC)
-					m.ChessSelected = true
-					m.ChessSelRow = m.CursorR
-					m.ChessSelCol = m.CursorC
-					m.ChessValidMoves = chess.GetLegalMoves(m.Game.ChessState, m.CursorR, m.CursorC)
-					log.Info("Legal moves calculated", "count", len(m.ChessValidMoves))
-				} else {
-					log.Info("Clicked opponent piece", "isWhite", isWhite, "myColorWhite", myColorWhite)
-				}
-			} else {
-				log.Info("Clicked empty square")
-			}
-		}
+// nextPollCmd keeps the fallback poller going; hub sessions don't need it
+func (m Model) nextPollCmd() tea.Cmd {
+	if m.usingHub() {
+		return nil
 	}
-	return m, nil
+	return pollCmd(m.Store, m.RoomCode, m.SessionID)
 }
 
-func pollCmd(code string) tea.Cmd {
-	return tea.Tick(time.Millisecond*500, func(t time.Time) tea.Msg {
-		r, err := db.GetRoom(code)
-		if err != nil {
-			if err.Error() == "room does not exist" {
-				return roomUpdateMsg{}
-			}
-			return pollErrorMsg(err)
-		}
-		if r == nil {
+func pollCmd(store db.Store, code, pid string) tea.Cmd {
+	return tea.Tick(config.SyncInterval, func(t time.Time) tea.Msg {
+		return fetchRoom(store, code, pid)
+	})
+}
+
+// fetchRoom reads the room as pid may see it
+func fetchRoom(store db.Store, code, pid string) tea.Msg {
+	r, err := store.GetRoom(code)
+	if err != nil {
+		if errors.Is(err, db.ErrRoomNotFound) {
 			return roomUpdateMsg{}
 		}
-		return roomUpdateMsg(*r)
-	})
+		return pollErrorMsg(err)
+	}
+	if r == nil {
+		return roomUpdateMsg{}
+	}
+	return roomUpdateMsg(r.For(pid))
 }
 
 // Updated Fetch Command
-func fetchPublicRoomsCmd() tea.Cmd {
+func fetchPublicRoomsCmd(store db.Store) tea.Cmd {
 	return func() tea.Msg {
-		rooms, err := db.GetPublicRooms()
+		rooms, err := store.GetPublicRooms()
 		if err != nil {
 			return errMsg(err)
 		}
+		// Browsing the lobby shows no more than spectating would
+		for i := range rooms {
+			rooms[i] = rooms[i].For("")
+		}
 		return roomsFetchedMsg(rooms)
 	}
 }
 
-func createRoomCmd(code, pid, name string, public bool, gameType string) tea.Cmd {
+func submitMoveCmd(store db.Store, code, pid, move string, version int64) tea.Cmd {
 	return func() tea.Msg {
-		if err := db.CreateRoom(code, pid, name, public, gameType); err != nil {
+		return moveResult(store, code, pid, store.SubmitMove(code, pid, move, version))
+	}
+}
+
+// moveResult turns a move error into a message. A stale move means our
+// snapshot is behind, so we resync by reading the room straight away.
+func moveResult(store db.Store, code, pid string, err error) tea.Msg {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, db.ErrStaleRoom) {
+		log.Info("Move rejected as stale, resyncing", "code", code)
+		return fetchRoom(store, code, pid)
+	}
+	log.Error("Move failed", "err", err)
+	return errMsg(fmt.Errorf("move failed: %v", err))
+}
+
+// restartGameCmd asks for a rematch. If the other player got there first
+// our snapshot is stale, so we resync and find their rematch under way.
+func restartGameCmd(store db.Store, code, pid, next string, version int64) tea.Cmd {
+	return func() tea.Msg {
+		err := store.RestartGame(code, pid, next, version)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, db.ErrStaleRoom) {
+			log.Info("Rematch rejected as stale, resyncing", "code", code)
+			return fetchRoom(store, code, pid)
+		}
+		log.Error("Rematch failed", "err", err)
+		return errMsg(fmt.Errorf("rematch failed: %v", err))
+	}
+}
+
+func createRoomCmd(store db.Store, code, pid, name string, opts db.RoomOptions) tea.Cmd {
+	return func() tea.Msg {
+		if err := store.CreateRoom(code, pid, name, opts); err != nil {
 			return errMsg(err)
 		}
-		return roomCreatedMsg{code: code, gameType: gameType}
+		return roomCreatedMsg{code: code, gameType: opts.GameType}
+	}
+}
+
+// clockCmd keeps the clocks ticking while a timed game is on. Once the
+// side to move shows no time left it asks the server to call the flag;
+// the server checks against its own clock, so a viewer can't flag early.
+func (m *Model) clockCmd() tea.Cmd {
+	g := m.Game
+	if m.ClockTicking || m.RoomCode == "" || g.Status != "playing" || g.Clock.RunningSince == 0 {
+		return nil
+	}
+	m.ClockTicking = true
+
+	var cmds []tea.Cmd
+	now := time.Now()
+	if g.Clock.Flagged(g.Turn, now) && m.FlagClaimed != g.Version {
+		m.FlagClaimed = g.Version
+		store, code := m.Store, m.RoomCode
+		cmds = append(cmds, func() tea.Msg {
+			if err := store.CheckFlag(code); err != nil {
+				log.Error("Flag check failed", "code", code, "err", err)
+			}
+			return nil
+		})
+	}
+
+	// Tenths are shown under ten seconds, so tick faster there
+	every := 500 * time.Millisecond
+	if g.Clock.Remaining(g.Turn, g.Turn, now) < 10*time.Second {
+		every = 100 * time.Millisecond
+	}
+	cmds = append(cmds, tea.Tick(every, func(time.Time) tea.Msg { return clockTickMsg{} }))
+	return tea.Batch(cmds...)
+}
+
+// copyCmd puts text on the player's clipboard with an OSC 52 escape, which
+// most modern terminals honour even over SSH.
+func copyCmd(w io.Writer, text string) tea.Cmd {
+	if w == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		if _, err := osc52.New(text).WriteTo(w); err != nil {
+			log.Error("Clipboard write failed", "err", err)
+		}
+		return nil
 	}
 }
 
-func joinRoomCmd(code, pid, name string) tea.Cmd {
+func joinRoomCmd(store db.Store, code, pid, name string) tea.Cmd {
 	return func() tea.Msg {
-		if err := db.JoinRoom(code, pid, name); err != nil {
+		if err := store.JoinRoom(code, pid, name); err != nil {
 			return errMsg(err)
 		}
 		// Determine role async
-		r, _ := db.GetRoom(code)
+		r, _ := store.GetRoom(code)
 		side := "O"
 		gameType := "tictactoe"
 		if r != nil {
@@ -0,0 +1,69 @@
+package ultimate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aminshahid573/termplay/internal/game"
+)
+
+// Game is Ultimate tic-tac-toe. Moves are cell indexes, 0 to 80.
+var Game = &game.Game{
+	ID:        "ultimate",
+	Name:      "Ultimate Tic Tac Toe",
+	Seats:     [2]string{game.X, game.O},
+	Rules:     rules{},
+	NewScreen: newScreen,
+}
+
+type rules struct{}
+
+func (rules) New(variant any) (game.State, error) {
+	return New(game.X), nil
+}
+
+func (rules) Decode(data []byte) (game.State, error) {
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if !s.Valid() {
+		return nil, fmt.Errorf("stored game has %d cells and %d boards", len(s.Cells), len(s.Boards))
+	}
+	return s, nil
+}
+
+func (s State) ToMove() string {
+	return s.Turn
+}
+
+func (s State) Check(seat, move string) error {
+	if seat != s.Turn {
+		return game.ErrNotYourTurn
+	}
+	idx, err := strconv.Atoi(move)
+	if err != nil {
+		return fmt.Errorf("cell %q is not a number", move)
+	}
+	if !s.Legal(idx) {
+		if idx >= 0 && idx < 81 && s.Active != -1 && idx/9 != s.Active {
+			return fmt.Errorf("must play in board %d", s.Active+1)
+		}
+		return fmt.Errorf("cell %d is not playable", idx)
+	}
+	return nil
+}
+
+func (s State) Apply(seat, move string) game.State {
+	idx, _ := strconv.Atoi(move)
+	return Play(s, idx)
+}
+
+func (s State) Outcome() game.Outcome {
+	return game.Outcome{Over: s.Status == "finished", Winner: s.Winner}
+}
+
+func (s State) Rematch(first string) game.State {
+	return New(first)
+}
@@ -0,0 +1,182 @@
+package ultimate
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aminshahid573/termplay/internal/game"
+	"github.com/aminshahid573/termplay/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// screen is a two-level cursor: board is the small board under the cursor
+// and r, c the cell inside it. While picking, the arrows move between small
+// boards instead of cells.
+type screen struct {
+	board   int
+	picking bool
+	r, c    int
+	// lastMove is State.LastMove as of the previous Sync
+	lastMove int
+}
+
+func newScreen() game.Screen {
+	return screen{r: 1, c: 1, lastMove: -2}
+}
+
+// Sync follows a new snapshot of the room. A forced board is entered
+// straight away; a free choice starts by picking a board.
+func (sc screen) Sync(v game.View) game.Screen {
+	s := v.State.(State)
+	if s.Active >= 0 {
+		sc.board = s.Active
+		sc.picking = false
+	} else if s.LastMove != sc.lastMove || !s.Open(sc.board) {
+		sc.picking = true
+	}
+	sc.lastMove = s.LastMove
+	return sc
+}
+
+func (sc screen) Update(v game.View, msg tea.KeyMsg) (game.Screen, game.Action) {
+	s := v.State.(State)
+
+	// Both levels move on a 3×3 grid
+	r, c := sc.r, sc.c
+	if sc.picking {
+		r, c = sc.board/3, sc.board%3
+	}
+	switch msg.String() {
+	case "up", "k":
+		r = max(r-1, 0)
+	case "down", "j":
+		r = min(r+1, 2)
+	case "left", "h":
+		c = max(c-1, 0)
+	case "right", "l":
+		c = min(c+1, 2)
+	case " ", "enter":
+		if !v.MyTurn() {
+			return sc, game.Action{}
+		}
+		if sc.picking {
+			if s.Playable(sc.board) {
+				sc.picking = false
+			}
+			return sc, game.Action{}
+		}
+		idx := sc.board*9 + sc.r*3 + sc.c
+		if s.Legal(idx) {
+			return sc, game.Action{Move: strconv.Itoa(idx)}
+		}
+		return sc, game.Action{}
+	case "esc":
+		if sc.picking || s.Active != -1 {
+			return sc, game.Action{Unhandled: true}
+		}
+		// Back out of a board chosen freely
+		sc.picking = true
+		return sc, game.Action{}
+	default:
+		return sc, game.Action{Unhandled: true}
+	}
+
+	if sc.picking {
+		sc.board = r*3 + c
+	} else {
+		sc.r, sc.c = r, c
+	}
+	return sc, game.Action{}
+}
+
+func (sc screen) Render(v game.View) string {
+	s := v.State.(State)
+	myTurn := v.MyTurn()
+	var bigRows []string
+	for br := 0; br < 3; br++ {
+		var boards []string
+		for bc := 0; bc < 3; bc++ {
+			boards = append(boards, sc.renderSmallBoard(s, br*3+bc, myTurn))
+		}
+		bigRows = append(bigRows, lipgloss.JoinHorizontal(lipgloss.Top, boards...))
+	}
+	board := lipgloss.JoinVertical(lipgloss.Center, bigRows...)
+
+	where := "any open board"
+	if s.Active >= 0 {
+		where = fmt.Sprintf("board %d", s.Active+1)
+	}
+	status := game.StatusLine(v, fmt.Sprintf("%s, in %s", s.Turn, where))
+	if myTurn && sc.picking {
+		status += " (pick a board)"
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Center,
+		styles.Title.Render("ULTIMATE TICTACTOE"),
+		game.Scoreboard(v),
+		"\n",
+		board,
+		"\n",
+		status,
+	)
+}
+
+func (sc screen) Help(v game.View) string {
+	return "Arrows: Move • Space: Pick Board/Place • Esc: Back • R: Restart • Q: Quit"
+}
+
+// renderSmallBoard draws small board b. Boards the side to move may play in
+// are outlined; a decided board shows only its owner.
+func (sc screen) renderSmallBoard(s State, b int, myTurn bool) string {
+	box := styles.SubBoard
+	if s.Playable(b) {
+		box = styles.SubBoardActive
+	}
+	for _, w := range s.WinningLine {
+		if w == b {
+			box = styles.SubBoardWin
+		}
+	}
+	if myTurn && sc.picking && b == sc.board {
+		box = styles.SubBoardCursor
+	}
+
+	switch owner := s.Boards[b]; owner {
+	case game.X, game.O:
+		mark := styles.XStyle.Render(owner)
+		if owner == game.O {
+			mark = styles.OStyle.Render(owner)
+		}
+		return box.Render(lipgloss.Place(9, 3, lipgloss.Center, lipgloss.Center, mark))
+	}
+
+	var rows []string
+	for r := 0; r < 3; r++ {
+		var cells []string
+		for c := 0; c < 3; c++ {
+			idx := b*9 + r*3 + c
+			style := styles.CellCompact
+			if myTurn && !sc.picking && b == sc.board && r == sc.r && c == sc.c {
+				style = styles.CellCompactSelected
+			}
+			content := styles.Muted.Render("·")
+			switch v := s.Cells[idx]; {
+			case s.Boards[b] == Draw && v != " ":
+				// A drawn board counts for nobody, so grey it out
+				content = styles.Muted.Render(v)
+			case v == game.X:
+				content = styles.XStyle.Render("X")
+			case v == game.O:
+				content = styles.OStyle.Render("O")
+			}
+			if idx == s.LastMove {
+				content = "[" + content + "]"
+			}
+			cells = append(cells, style.Render(content))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+	return box.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
@@ -0,0 +1,112 @@
+// Package ultimate holds the rules for Ultimate tic-tac-toe: nine small
+// boards in a 3×3 grid. Winning a small board claims its square on the big
+// board, and each move sends the opponent to the small board matching the
+// cell just played.
+package ultimate
+
+import "github.com/aminshahid573/termplay/internal/tictactoe"
+
+// Draw marks a small board that filled up without a winner
+const Draw = "D"
+
+// State is a whole game. Small board b, cell c is Cells[b*9+c]; both
+// count left to right, top to bottom.
+type State struct {
+	Cells       []string `json:"cells,omitempty"`
+	Boards      []string `json:"boards,omitempty"`      // Owner of each small board: X, O, Draw or " "
+	Active      int      `json:"active"`                // Small board to play in, -1 for any
+	Turn        string   `json:"turn,omitempty"`        // "X" or "O"
+	Status      string   `json:"status,omitempty"`      // "playing" or "finished"
+	Winner      string   `json:"winner,omitempty"`      // "X", "O", or "" for a draw
+	WinningLine []int    `json:"winningLine,omitempty"` // Small boards in the winning row
+	LastMove    int      `json:"lastMove"`              // Cell index, -1 before the first move
+}
+
+// New returns an empty game with first to move anywhere
+func New(first string) State {
+	s := State{
+		Cells:    make([]string, 81),
+		Boards:   make([]string, 9),
+		Active:   -1,
+		Turn:     first,
+		Status:   "playing",
+		LastMove: -1,
+	}
+	for i := range s.Cells {
+		s.Cells[i] = " "
+	}
+	for i := range s.Boards {
+		s.Boards[i] = " "
+	}
+	return s
+}
+
+// Valid reports whether s has a full set of cells and boards, as a
+// stored game should
+func (s State) Valid() bool {
+	return len(s.Cells) == 81 && len(s.Boards) == 9
+}
+
+// Open reports whether small board b can still be played in
+func (s State) Open(b int) bool {
+	return b >= 0 && b < 9 && s.Boards[b] == " "
+}
+
+// Playable reports whether the side to move may play in small board b now
+func (s State) Playable(b int) bool {
+	return s.Status == "playing" && s.Open(b) && (s.Active == -1 || s.Active == b)
+}
+
+// Legal reports whether the side to move may play cell idx
+func (s State) Legal(idx int) bool {
+	return idx >= 0 && idx < 81 && s.Playable(idx/9) && s.Cells[idx] == " "
+}
+
+// Play puts the side to move's mark on cell idx, which must be Legal, and
+// returns the new state. s itself is left alone.
+func Play(s State, idx int) State {
+	s.Cells = append([]string(nil), s.Cells...)
+	s.Boards = append([]string(nil), s.Boards...)
+	s.Cells[idx] = s.Turn
+	s.LastMove = idx
+
+	b := idx / 9
+	small := s.Cells[b*9 : b*9+9]
+	if w, _ := tictactoe.Classic.CheckWinner(small); w != "" {
+		s.Boards[b] = w
+	} else if tictactoe.CheckDraw(small) {
+		s.Boards[b] = Draw
+	}
+
+	// Drawn small boards count for nobody on the big board
+	big := make([]string, 9)
+	open := false
+	for i, owner := range s.Boards {
+		big[i] = owner
+		if owner == Draw {
+			big[i] = " "
+		}
+		open = open || owner == " "
+	}
+	if w, line := tictactoe.Classic.CheckWinner(big); w != "" {
+		s.Status, s.Winner, s.WinningLine = "finished", w, line
+		return s
+	}
+	if !open {
+		s.Status = "finished"
+		return s
+	}
+
+	// The cell played picks the opponent's board, unless that one is
+	// decided, in which case they may go anywhere
+	s.Active = idx % 9
+	if !s.Open(s.Active) {
+		s.Active = -1
+	}
+	if s.Turn == "X" {
+		s.Turn = "O"
+	} else {
+		s.Turn = "X"
+	}
+	return s
+}